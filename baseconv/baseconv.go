@@ -0,0 +1,335 @@
+// Package baseconv 在 2 ~ 62 进制和自定义字母表之间转换整数。
+//
+// strconv.ParseInt(s, 16, 0) 和 %b、%o、%x 最多只支持 36 进制，数字字符也固定为 0-9a-z。
+// 用整数主键生成短 ID 时常用更大的进制和特定的字母表：
+//
+//	Base62      0-9a-zA-Z，前 36 位与 strconv 一致
+//	Base58      比特币地址使用的字母表，去掉了容易混淆的 0、O、I、l
+//	Crockford   Crockford Base32，不区分大小写，I/L 当作 1、O 当作 0，可以附加校验位
+//	URLSafe     A-Za-z0-9-_，可以直接放进 URL
+//
+// 这里按位置记数法转换整数，不是按字节编码：前导 0 不会保留，
+// 与比特币对字节串做的 Base58 编码（每个前导 0 字节编码为一个 '1'）不同。
+package baseconv
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"unicode/utf8"
+)
+
+// 解析失败的原因，可以用 errors.Is 判断
+var (
+	ErrSyntax   = errors.New("baseconv: 无效字符")
+	ErrRange    = errors.New("baseconv: 超出范围")
+	ErrChecksum = errors.New("baseconv: 校验位不匹配")
+)
+
+// Error 解析失败的详细信息
+type Error struct {
+	Func   string // 出错的函数，如 "DecodeUint64"
+	Input  string
+	Offset int    // 出错位置在 Input 中的字节下标
+	Reason string // 具体原因
+	Err    error  // ErrSyntax、ErrRange 或 ErrChecksum
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("baseconv.%s: 解析 %q 失败：%s", e.Func, e.Input, e.Reason)
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Alphabet 一组数字字符，第 i 个字符表示数字 i，字符个数即进制
+type Alphabet struct {
+	digits string
+	index  [256]int16 // 字符 → 数字；-1 表示无效字符，-2 表示解析时忽略的字符
+	std    string     // base ≤ 62 时，digits 对应的 math/big 标准数字，用于转换 big.Int
+}
+
+const stdDigits = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// 常用字母表
+var (
+	Base62    = mustAlphabet(stdDigits)
+	Base58    = mustAlphabet("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+	Crockford = crockford()
+	URLSafe   = mustAlphabet("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_")
+)
+
+// NewAlphabet 用 digits 中的字符（ASCII 字符、互不相同，至少 2 个）创建字母表，解析时区分大小写
+func NewAlphabet(digits string) (*Alphabet, error) {
+	if len(digits) < 2 || len(digits) > 128 {
+		return nil, fmt.Errorf("baseconv: 字母表需要 2 ~ 128 个字符，%q 有 %d 个", digits, len(digits))
+	}
+	a := &Alphabet{digits: digits}
+	for i := range a.index {
+		a.index[i] = -1
+	}
+	for i := 0; i < len(digits); i++ {
+		c := digits[i]
+		if c >= 0x80 {
+			return nil, fmt.Errorf("baseconv: 字母表只能包含 ASCII 字符，第 %d 个字节为 %#x", i, c)
+		}
+		if a.index[c] >= 0 {
+			return nil, fmt.Errorf("baseconv: 字母表中的 %q 重复出现", c)
+		}
+		a.index[c] = int16(i)
+	}
+	if len(digits) <= len(stdDigits) {
+		a.std = stdDigits[:len(digits)]
+	}
+	return a, nil
+}
+
+func mustAlphabet(digits string) *Alphabet {
+	a, err := NewAlphabet(digits)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// String 字母表中的全部字符
+func (a *Alphabet) String() string { return a.digits }
+
+// Base 进制，即字母表的字符个数
+func (a *Alphabet) Base() int { return len(a.digits) }
+
+// signed 能否用前导 '-' 表示负数：'-' 本身是数字字符的字母表（如 URLSafe）不能。
+// Crockford 中的 '-' 是分组符，只有出现在开头时表示负号。
+func (a *Alphabet) signed() bool { return a.index['-'] < 0 }
+
+// EncodeUint64 n 在该字母表下的表示，0 编码为第一个字符
+func (a *Alphabet) EncodeUint64(n uint64) string {
+	var buf [64]byte // 2 进制时 uint64 最多 64 位
+	i := len(buf)
+	base := uint64(len(a.digits))
+	for {
+		i--
+		q := n / base
+		buf[i] = a.digits[n-q*base]
+		n = q
+		if n == 0 {
+			break
+		}
+	}
+	return string(buf[i:])
+}
+
+// EncodeInt64 n 在该字母表下的表示，负数加前导 '-'。
+// 字母表中包含 '-' 时无法区分负号，传入负数会 panic。
+func (a *Alphabet) EncodeInt64(n int64) string {
+	if n >= 0 {
+		return a.EncodeUint64(uint64(n))
+	}
+	if !a.signed() {
+		panic("baseconv: 字母表 " + a.digits + " 包含 '-'，不能表示负数")
+	}
+	return "-" + a.EncodeUint64(-uint64(n))
+}
+
+// DecodeUint64 解析 EncodeUint64 的结果
+func (a *Alphabet) DecodeUint64(s string) (uint64, error) {
+	const fn = "DecodeUint64"
+	base := uint64(len(a.digits))
+	var n uint64
+	digits := 0
+	for i := 0; i < len(s); i++ {
+		d := a.index[s[i]]
+		if d == -2 {
+			if !a.between(s, i) {
+				return 0, a.separatorError(fn, s, i)
+			}
+			continue
+		}
+		if d < 0 {
+			return 0, a.syntaxError(fn, s, i)
+		}
+		hi, lo := bits.Mul64(n, base)
+		lo, carry := bits.Add64(lo, uint64(d), 0)
+		if hi != 0 || carry != 0 {
+			return 0, &Error{Func: fn, Input: s, Offset: 0, Reason: "超出 uint64 的范围", Err: ErrRange}
+		}
+		n = lo
+		digits++
+	}
+	if digits == 0 {
+		return 0, &Error{Func: fn, Input: s, Offset: len(s), Reason: "没有数字", Err: ErrSyntax}
+	}
+	return n, nil
+}
+
+// DecodeInt64 解析 EncodeInt64 的结果
+func (a *Alphabet) DecodeInt64(s string) (int64, error) {
+	const fn = "DecodeInt64"
+	neg := len(s) > 0 && s[0] == '-' && a.signed()
+	digits := s
+	if neg {
+		digits = s[1:]
+	}
+	u, err := a.DecodeUint64(digits)
+	if err != nil {
+		var e *Error
+		if errors.As(err, &e) {
+			e.Func, e.Input = fn, s
+			if neg && e.Err == ErrSyntax {
+				e.Offset++
+			}
+		}
+		return 0, err
+	}
+	if neg && u <= 1<<63 {
+		return -int64(u), nil
+	}
+	if !neg && u < 1<<63 {
+		return int64(u), nil
+	}
+	return 0, &Error{Func: fn, Input: s, Reason: "超出 int64 的范围", Err: ErrRange}
+}
+
+// EncodeBig n 在该字母表下的表示，负数加前导 '-'（规则同 EncodeInt64）
+func (a *Alphabet) EncodeBig(n *big.Int) string {
+	if n.Sign() < 0 && !a.signed() {
+		panic("baseconv: 字母表 " + a.digits + " 包含 '-'，不能表示负数")
+	}
+	if a.std != "" { // 先用 math/big 转成标准数字，再逐字符替换
+		b := []byte(n.Text(len(a.digits)))
+		for i, c := range b {
+			if c != '-' {
+				b[i] = a.digits[stdIndex(c)]
+			}
+		}
+		return string(b)
+	}
+	// 超过 62 进制时 math/big 不支持，逐位取余
+	var q, r big.Int
+	q.Abs(n)
+	base := big.NewInt(int64(len(a.digits)))
+	var b []byte
+	for {
+		q.QuoRem(&q, base, &r)
+		b = append(b, a.digits[r.Int64()])
+		if q.Sign() == 0 {
+			break
+		}
+	}
+	if n.Sign() < 0 {
+		b = append(b, '-')
+	}
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
+
+// DecodeBig 解析 EncodeBig 的结果
+func (a *Alphabet) DecodeBig(s string) (*big.Int, error) {
+	const fn = "DecodeBig"
+	neg := len(s) > 0 && s[0] == '-' && a.signed()
+	start := 0
+	if neg {
+		start = 1
+	}
+	n := new(big.Int)
+	base := big.NewInt(int64(len(a.digits)))
+	digits := 0
+	var d big.Int
+	for i := start; i < len(s); i++ {
+		v := a.index[s[i]]
+		if v == -2 {
+			if i == start || !a.between(s, i) {
+				return nil, a.separatorError(fn, s, i)
+			}
+			continue
+		}
+		if v < 0 {
+			return nil, a.syntaxError(fn, s, i)
+		}
+		n.Mul(n, base).Add(n, d.SetInt64(int64(v)))
+		digits++
+	}
+	if digits == 0 {
+		return nil, &Error{Func: fn, Input: s, Offset: len(s), Reason: "没有数字", Err: ErrSyntax}
+	}
+	if neg {
+		n.Neg(n)
+	}
+	return n, nil
+}
+
+func (a *Alphabet) syntaxError(fn, s string, i int) *Error {
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	return &Error{Func: fn, Input: s, Offset: i,
+		Reason: fmt.Sprintf("第 %d 字节处的 %q 不在 %d 进制字母表中", i, r, len(a.digits)), Err: ErrSyntax}
+}
+
+// between s[i] 前后是否都是数字：忽略的分组符只能出现在两个数字之间
+func (a *Alphabet) between(s string, i int) bool {
+	return i > 0 && i+1 < len(s) && a.index[s[i-1]] >= 0 && a.index[s[i+1]] >= 0
+}
+
+func (a *Alphabet) separatorError(fn, s string, i int) *Error {
+	return &Error{Func: fn, Input: s, Offset: i,
+		Reason: fmt.Sprintf("第 %d 字节处的分组符 %q 只能出现在两个数字之间", i, s[i]), Err: ErrSyntax}
+}
+
+func stdIndex(c byte) int {
+	switch {
+	case c <= '9':
+		return int(c - '0')
+	case c >= 'a' && c <= 'z':
+		return int(c-'a') + 10
+	}
+	return int(c-'A') + 36
+}
+
+// Encode 与 strconv.FormatUint 相同，但 base 可以到 62，超过 36 的数字依次用 A-Z 表示。
+// base 不在 2 ~ 62 之间时 panic。
+func Encode(n uint64, base int) string {
+	return stdAlphabet(base).EncodeUint64(n)
+}
+
+// Decode 与 strconv.ParseUint(s, base, 64) 相同，但 base 可以到 62。
+// base ≤ 36 时与 strconv 一样不区分大小写。
+func Decode(s string, base int) (uint64, error) {
+	if base < 2 || base > 62 {
+		return 0, fmt.Errorf("baseconv: 无效的进制 %d", base)
+	}
+	if base <= 36 {
+		s = lowerASCII(s)
+	}
+	return stdAlphabet(base).DecodeUint64(s)
+}
+
+var stdAlphabets [63]*Alphabet
+
+func init() {
+	for base := 2; base <= 62; base++ {
+		stdAlphabets[base] = mustAlphabet(stdDigits[:base])
+	}
+}
+
+func stdAlphabet(base int) *Alphabet {
+	if base < 2 || base > 62 {
+		panic(fmt.Sprintf("baseconv: 无效的进制 %d", base))
+	}
+	return stdAlphabets[base]
+}
+
+func lowerASCII(s string) string {
+	for i := 0; i < len(s); i++ {
+		if 'A' <= s[i] && s[i] <= 'Z' {
+			b := []byte(s)
+			for j := i; j < len(b); j++ {
+				if 'A' <= b[j] && b[j] <= 'Z' {
+					b[j] += 'a' - 'A'
+				}
+			}
+			return string(b)
+		}
+	}
+	return s
+}
@@ -0,0 +1,200 @@
+package baseconv
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestEncodeMatchesStrconv(t *testing.T) {
+	for base := 2; base <= 36; base++ {
+		for _, n := range []uint64{0, 1, 35, 36, 255, 1 << 32, math.MaxInt64, math.MaxUint64} {
+			want := strconv.FormatUint(n, base)
+			if got := Encode(n, base); got != want {
+				t.Errorf("Encode(%d, %d) = %q，期望 %q", n, base, got, want)
+			}
+			if got, err := Decode(want, base); err != nil || got != n {
+				t.Errorf("Decode(%q, %d) = %d, %v", want, base, got, err)
+			}
+		}
+	}
+	if got, err := Decode("FF", 16); err != nil || got != 255 {
+		t.Errorf("Decode(\"FF\", 16) = %d, %v", got, err)
+	}
+}
+
+func TestKnownValues(t *testing.T) {
+	for _, tt := range []struct {
+		a    *Alphabet
+		n    uint64
+		want string
+	}{
+		{Base62, 61, "Z"},
+		{Base62, 62, "10"},
+		{Base62, math.MaxUint64, "lYGhA16ahyf"},
+		{Base58, 0, "1"},
+		{Base58, 57, "z"},
+		{Base58, 58, "21"},
+		{Crockford, 1234, "16J"},
+		{Crockford, 31, "Z"},
+		{URLSafe, 63, "_"},
+		{URLSafe, 64, "BA"},
+	} {
+		if got := tt.a.EncodeUint64(tt.n); got != tt.want {
+			t.Errorf("%d 进制 EncodeUint64(%d) = %q，期望 %q", tt.a.Base(), tt.n, got, tt.want)
+		}
+		if got, err := tt.a.DecodeUint64(tt.want); err != nil || got != tt.n {
+			t.Errorf("%d 进制 DecodeUint64(%q) = %d, %v", tt.a.Base(), tt.want, got, err)
+		}
+	}
+}
+
+func TestBase58Bitcoin(t *testing.T) {
+	// 没有前导 0 字节时，按整数编码与比特币对字节串的 Base58 编码结果相同
+	n := new(big.Int).SetBytes([]byte("Hello World!"))
+	if got, want := Base58.EncodeBig(n), "2NEpo7TZRRrLZSi2U"; got != want {
+		t.Errorf("EncodeBig = %q，期望 %q", got, want)
+	}
+	got, err := Base58.DecodeBig("2NEpo7TZRRrLZSi2U")
+	if err != nil || string(got.Bytes()) != "Hello World!" {
+		t.Errorf("DecodeBig = %v, %v", got, err)
+	}
+}
+
+func TestBig(t *testing.T) {
+	n, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	for _, a := range []*Alphabet{Base62, Base58, Crockford, mustAlphabet("01")} {
+		s := a.EncodeBig(n)
+		got, err := a.DecodeBig(s)
+		if err != nil || got.Cmp(n) != 0 {
+			t.Errorf("%d 进制 DecodeBig(%q) = %v, %v", a.Base(), s, got, err)
+		}
+	}
+	// URLSafe 超过 62 进制，走逐位取余的路径，结果应与 EncodeUint64 一致
+	for _, v := range []uint64{0, 63, 64, math.MaxUint64} {
+		if got, want := URLSafe.EncodeBig(new(big.Int).SetUint64(v)), URLSafe.EncodeUint64(v); got != want {
+			t.Errorf("URLSafe.EncodeBig(%d) = %q，期望 %q", v, got, want)
+		}
+	}
+}
+
+func TestInt64(t *testing.T) {
+	for _, n := range []int64{0, -1, 42, math.MinInt64, math.MaxInt64} {
+		s := Base62.EncodeInt64(n)
+		if got, err := Base62.DecodeInt64(s); err != nil || got != n {
+			t.Errorf("DecodeInt64(%q) = %d, %v，期望 %d", s, got, err, n)
+		}
+	}
+	if got, err := Base62.DecodeInt64("-" + Base62.EncodeUint64(1<<63+1)); !errors.Is(err, ErrRange) {
+		t.Errorf("超出 int64 范围的负数 = %d, %v", got, err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("URLSafe 编码负数应当 panic")
+		}
+	}()
+	URLSafe.EncodeInt64(-1)
+}
+
+func TestCrockford(t *testing.T) {
+	for _, s := range []string{"16J", "16j", "I6J", "l6J", "1-6J", "1-6-J"} {
+		if got, err := Crockford.DecodeUint64(s); err != nil || got != 1234 {
+			t.Errorf("DecodeUint64(%q) = %d, %v", s, got, err)
+		}
+	}
+	if got, err := Crockford.DecodeUint64("0O1"); err != nil || got != 1 {
+		t.Errorf("DecodeUint64(\"0O1\") = %d, %v", got, err)
+	}
+	if got, err := Crockford.DecodeInt64("-1-6J"); err != nil || got != -1234 {
+		t.Errorf("DecodeInt64(\"-1-6J\") = %d, %v", got, err)
+	}
+	if got, err := Crockford.DecodeBig("-1-6J"); err != nil || got.Int64() != -1234 {
+		t.Errorf("DecodeBig(\"-1-6J\") = %v, %v", got, err)
+	}
+	if got := EncodeCrockfordCheck(1234); got != "16JD" {
+		t.Errorf("EncodeCrockfordCheck(1234) = %q", got)
+	}
+	for _, n := range []uint64{0, 1, 36, 37, 1 << 40, math.MaxUint64} {
+		s := EncodeCrockfordCheck(n)
+		if got, err := DecodeCrockfordCheck(s); err != nil || got != n {
+			t.Errorf("DecodeCrockfordCheck(%q) = %d, %v", s, got, err)
+		}
+	}
+	if _, err := DecodeCrockfordCheck("16JE"); !errors.Is(err, ErrChecksum) {
+		t.Errorf("校验位错误时返回 %v", err)
+	}
+	if _, err := DecodeCrockfordCheck("17JD"); !errors.Is(err, ErrChecksum) {
+		t.Errorf("数字错误时返回 %v", err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		err    error
+		want   error
+		offset int
+	}{
+		{"无效字符", func() error { _, err := Base58.DecodeUint64("2N0p"); return err }(), ErrSyntax, 2},
+		{"中文", func() error { _, err := Base62.DecodeUint64("ab语"); return err }(), ErrSyntax, 2},
+		{"空输入", func() error { _, err := Base62.DecodeUint64(""); return err }(), ErrSyntax, 0},
+		{"只有负号", func() error { _, err := Base62.DecodeInt64("-"); return err }(), ErrSyntax, 1},
+		{"负号后无效字符", func() error { _, err := Base62.DecodeInt64("-a!"); return err }(), ErrSyntax, 2},
+		{"溢出", func() error { _, err := Base62.DecodeUint64("lYGhA16ahyg"); return err }(), ErrRange, 0},
+		{"U 不是数字", func() error { _, err := Crockford.DecodeUint64("1U"); return err }(), ErrSyntax, 1},
+		{"开头的分组符", func() error { _, err := Crockford.DecodeUint64("-7Z"); return err }(), ErrSyntax, 0},
+		{"末尾的分组符", func() error { _, err := Crockford.DecodeUint64("7Z-"); return err }(), ErrSyntax, 2},
+		{"连续的分组符", func() error { _, err := Crockford.DecodeUint64("7--Z"); return err }(), ErrSyntax, 1},
+		{"负号后的分组符", func() error { _, err := Crockford.DecodeInt64("--7Z"); return err }(), ErrSyntax, 1},
+		{"DecodeBig 负号后的分组符", func() error { _, err := Crockford.DecodeBig("--7Z"); return err }(), ErrSyntax, 1},
+		{"缺少校验位", func() error { _, err := DecodeCrockfordCheck("1"); return err }(), ErrSyntax, 1},
+	} {
+		var e *Error
+		if !errors.As(tt.err, &e) || !errors.Is(tt.err, tt.want) || e.Offset != tt.offset {
+			t.Errorf("%s：错误为 %v，期望 %v、位置 %d", tt.name, tt.err, tt.want, tt.offset)
+		}
+	}
+	if _, err := NewAlphabet("abca"); err == nil {
+		t.Error("重复字符的字母表应当报错")
+	}
+	ascii := make([]byte, 128)
+	for i := range ascii {
+		ascii[i] = byte(i)
+	}
+	if a, err := NewAlphabet(string(ascii)); err != nil || a.Base() != 128 {
+		t.Errorf("128 个 ASCII 字符的字母表：%v", err)
+	}
+	if _, err := NewAlphabet(string(ascii) + "\x80"); err == nil || !strings.Contains(err.Error(), "2 ~ 128 个字符") {
+		t.Errorf("129 个字符的字母表：错误为 %v", err)
+	}
+	if _, err := Decode("1", 63); err == nil {
+		t.Error("63 进制应当报错")
+	}
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add(uint64(0), int64(0))
+	f.Add(uint64(math.MaxUint64), int64(math.MinInt64))
+	f.Fuzz(func(t *testing.T, u uint64, i int64) {
+		for _, a := range []*Alphabet{Base62, Base58, Crockford, URLSafe} {
+			if got, err := a.DecodeUint64(a.EncodeUint64(u)); err != nil || got != u {
+				t.Fatalf("%d 进制 uint64 %d 往返得到 %d, %v", a.Base(), u, got, err)
+			}
+			b := new(big.Int).SetUint64(u)
+			if got, err := a.DecodeBig(a.EncodeBig(b)); err != nil || got.Cmp(b) != 0 {
+				t.Fatalf("%d 进制 big.Int %d 往返得到 %v, %v", a.Base(), u, got, err)
+			}
+		}
+		for _, a := range []*Alphabet{Base62, Base58, Crockford} {
+			if got, err := a.DecodeInt64(a.EncodeInt64(i)); err != nil || got != i {
+				t.Fatalf("%d 进制 int64 %d 往返得到 %d, %v", a.Base(), i, got, err)
+			}
+		}
+		if got, want := Base62.EncodeBig(new(big.Int).SetUint64(u)), Base62.EncodeUint64(u); got != want {
+			t.Fatalf("EncodeBig(%d) = %q，EncodeUint64 = %q", u, got, want)
+		}
+	})
+}
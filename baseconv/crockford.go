@@ -0,0 +1,78 @@
+package baseconv
+
+import (
+	"errors"
+	"strings"
+)
+
+// Crockford Base32 的规则（https://www.crockford.com/base32.html）：
+// 字母表去掉了 I、L、O、U，解析时不区分大小写，I、L 当作 1，O 当作 0，
+// 连字符 '-' 只用于分组：解析时忽略两个数字之间的 '-'，开头、末尾或连续的 '-' 是语法错误
+// （DecodeInt64、DecodeBig 开头的 '-' 表示负号）。
+// 可以在末尾附加一个校验位：数值对 37 取余，用 "0-9A-Z（去掉 I、L、O、U）*~$=U" 中的字符表示。
+
+const crockfordDigits = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// crockfordCheck 校验位字符，前 32 个与数字字符相同
+const crockfordCheck = crockfordDigits + "*~$=U"
+
+func crockford() *Alphabet {
+	a := mustAlphabet(crockfordDigits)
+	for i := 0; i < len(crockfordDigits); i++ {
+		c := crockfordDigits[i]
+		if 'A' <= c && c <= 'Z' {
+			a.index[c+'a'-'A'] = a.index[c]
+		}
+	}
+	for _, c := range "iIlL" {
+		a.index[c] = 1
+	}
+	a.index['o'], a.index['O'] = 0, 0
+	a.index['-'] = -2
+	return a
+}
+
+// EncodeCrockfordCheck n 的 Crockford Base32 表示，末尾附加校验位
+func EncodeCrockfordCheck(n uint64) string {
+	return Crockford.EncodeUint64(n) + string(crockfordCheck[n%37])
+}
+
+// DecodeCrockfordCheck 解析 EncodeCrockfordCheck 的结果，校验位不匹配时返回 ErrChecksum
+func DecodeCrockfordCheck(s string) (uint64, error) {
+	const fn = "DecodeCrockfordCheck"
+	if len(s) < 2 {
+		return 0, &Error{Func: fn, Input: s, Offset: len(s), Reason: "缺少校验位", Err: ErrSyntax}
+	}
+	body, check := s[:len(s)-1], s[len(s)-1]
+	want := strings.IndexByte(crockfordCheck, upper(check))
+	if want < 0 {
+		if v := Crockford.index[check]; v >= 0 { // 小写字母与 I、L、O 的别名同样可以作为校验位
+			want = int(v)
+		} else {
+			return 0, &Error{Func: fn, Input: s, Offset: len(s) - 1,
+				Reason: "最后一位 " + quoteByte(check) + " 不是校验位", Err: ErrSyntax}
+		}
+	}
+	n, err := Crockford.DecodeUint64(body)
+	if err != nil {
+		var e *Error
+		if errors.As(err, &e) {
+			e.Func, e.Input = fn, s
+		}
+		return 0, err
+	}
+	if got := int(n % 37); got != want {
+		return 0, &Error{Func: fn, Input: s, Offset: len(s) - 1,
+			Reason: "校验位应为 " + quoteByte(crockfordCheck[got]) + "，实际为 " + quoteByte(check), Err: ErrChecksum}
+	}
+	return n, nil
+}
+
+func upper(c byte) byte {
+	if 'a' <= c && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+func quoteByte(c byte) string { return "'" + string(rune(c)) + "'" }
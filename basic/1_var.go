@@ -1,10 +1,26 @@
-package main
+package basic
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/colayear/go_learning/lesson"
+	"github.com/colayear/go_learning/strutil"
+)
+
+func init() {
+	lesson.Register(lesson.Lesson{
+		ID:    "1_var",
+		Title: "变量声明及赋值",
+		Sections: []lesson.Section{
+			{ID: "varSection1", Title: "变量声明及赋值", Run: varSection1},
+			{ID: "varSection2", Title: "中英文混排的表格对齐", Run: varSection2},
+		},
+	})
+}
 
 // ============= 变量声明及赋值 ==================
 // 此文件展示变量的声明及赋值和go语言的特性
-func main() {
+func varSection1() {
 
 	// 标准声明方式
 	var name string = "张三"
@@ -92,7 +108,7 @@ func main() {
 	// 类型不兼容赋值
 	var intVar int = 10
 	// 错误示例（注释掉，运行时取消注释会报错）：
-	// floatVar := intVar // 报错：cannot use intVar (type int) as type float64 in assignment
+	// var floatVar float64 = intVar // 报错：cannot use intVar (variable of type int) as float64 value in variable declaration
 	// 正确做法：显式类型转换
 	floatVar := float64(intVar)
 	fmt.Printf("\n类型转换后 - intVar(%d) -> floatVar(%f)\n", intVar, floatVar)
@@ -101,5 +117,39 @@ func main() {
 	// 常量用const声明，值不可修改，编译期确定，常用于固定配置
 	const PI = 3.1415926
 	fmt.Printf("\n常量PI: %f\n", PI)
-	// PI = 3.14 // 报错：cannot assign to PI (untyped float constant)
+	// PI = 3.14 // 报错：cannot assign to PI (neither addressable nor a map index expression)
+	// 原因：常量的值在编译期确定，之后不能再赋值
+}
+
+// ============= 中英文混排的表格对齐 ==================
+// fmt的%-10s按字符数补空格，但中文在终端里占两列，英文占一列，表格会错位
+// strutil按显示宽度（列数）补齐和截断
+func varSection2() {
+	students := []struct {
+		name  string
+		city  string
+		score float64
+	}{
+		{"张三", "北京市海淀区", 98.5},
+		{"李老师", "上海", 100},
+		{"Tom", "New York", 87},
+		{"欧阳Nana", "杭州Hangzhou", 92.5},
+	}
+
+	fmt.Println("=== fmt按字符数补齐（中文会错位） ===")
+	for _, s := range students {
+		fmt.Printf("|%-10s|%-12s|%6.1f|\n", s.name, s.city, s.score)
+	}
+
+	fmt.Println("\n=== strutil按显示宽度补齐 ===")
+	for _, s := range students {
+		fmt.Printf("|%s|%s|%6.1f|\n", strutil.PadRight(s.name, 10), strutil.PadRight(s.city, 12), s.score)
+	}
+
+	// 超出列宽时截断，不会截断半个汉字
+	fmt.Println("\n=== 超出列宽时截断 ===")
+	address := "北京市海淀区中关村大街1号"
+	fmt.Printf("显示宽度：%d（字符数：%d，字节数：%d）\n", strutil.Width(address), strutil.RuneLen(address), len(address))
+	fmt.Printf("|%s|\n", strutil.Fit(address, 12, "…"))
+	fmt.Printf("|%s|\n", strutil.Fit("Tom", 12, "…"))
 }
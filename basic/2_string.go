@@ -1,13 +1,38 @@
-package main
+package basic
 
 import (
+	_ "embed"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"unsafe"
+
+	"github.com/colayear/go_learning/baseconv"
+	"github.com/colayear/go_learning/convert"
+	"github.com/colayear/go_learning/grapheme"
+	"github.com/colayear/go_learning/lenient"
+	"github.com/colayear/go_learning/lesson"
+	"github.com/colayear/go_learning/strutil"
 )
 
-func main() {
+func init() {
+	lesson.Register(lesson.Lesson{
+		ID:    "2_string",
+		Title: "字符串",
+		Sections: []lesson.Section{
+			{ID: "stringSection1", Title: "字符串的声明、操作与转换", Run: stringSection1},
+			{ID: "stringSection2", Title: "字节、字符（rune）与字素簇", Run: stringSection2},
+		},
+	})
+}
+
+// concatBench 字符串拼接基准测试的汇总表，见 concat 包
+//
+//go:embed concat_bench.txt
+var concatBench string
+
+func stringSection1() {
 	// ===================== 字符串的声明方式 =====================
 	// 方式1：标准声明（显式类型）
 	var str1 string = "Hello Go语言"
@@ -43,6 +68,7 @@ func main() {
 	// ===================== 字符类型（rune）：处理中文等多字节字符 =====================
 	// rune是int32的别名，代表一个UTF-8字符（无论占几个字节）
 	// 遍历字符串的正确方式：for range（按rune遍历）
+	// 注意：一个rune不一定是用户看到的“一个字”，emoji、国旗、组合字符由多个rune组成，见stringSection2
 	fmt.Println("=== 按rune遍历字符串（正确处理中文） ===")
 	for index, char := range str4 {
 		fmt.Printf("索引：%d，字符：%c（rune值：%d）\n", index, char, char)
@@ -79,19 +105,25 @@ func main() {
 
 	// ===================== 字符串截取（切片） =====================
 	// 知识点：截取基于字节索引，需注意中文边界（避免截断UTF-8字符）
+	// strutil.Slice(s, i, j) 与 s[i:j] 完全相同，golearn run --debug-slices 运行时会报告被截断的字符
 	fmt.Println("=== 字符串截取 ===")
 	str8 := "Go语言编程"
 	// 截取前2个字节（Go）
-	sub1 := str8[0:2] // 等价于 str8[:2]
+	sub1 := strutil.Slice(str8, 0, 2) // 即 str8[0:2]，等价于 str8[:2]
 	fmt.Printf("截取前2字节：%s\n", sub1)
 
 	// 截取"语言"："语"从第2字节开始，占3字节；"言"占3字节 → 2~8字节
-	sub2 := str8[2:8]
+	sub2 := strutil.Slice(str8, 2, 8) // 即 str8[2:8]
 	fmt.Printf("截取语言：%s\n", sub2)
 
 	// 错误示例：截断中文（第3字节开始，只取2字节 → 乱码）
-	sub3 := str8[2:4]
-	fmt.Printf("错误截取（截断中文）：%s\n\n", sub3)
+	sub3 := strutil.Slice(str8, 2, 4) // 即 str8[2:4]
+	fmt.Printf("错误截取（截断中文）：%s\n", sub3)
+	// 截取前先检查边界：ValidateSlice会指出哪个下标截断了哪个字符
+	if err := strutil.ValidateSlice(str8, 2, 4); err != nil {
+		fmt.Printf("边界检查：%v\n", err)
+	}
+	fmt.Printf("字节下标2是否为字符边界：%t，下标4：%t\n\n", strutil.IsBoundary(str8, 2), strutil.IsBoundary(str8, 4))
 
 	// ===================== 字符串修改（间接修改） =====================
 	// 知识点：字符串不可变，需转为[]byte/[]rune修改后转回
@@ -201,6 +233,10 @@ func main() {
 	// ParseInt(字符串, 进制, 位数)：位数0表示自动适配，64表示int64
 	hexNum, _ := strconv.ParseInt(hexStr, 16, 0)
 	fmt.Printf("十六进制'%s'转整数：%d\n", hexStr, hexNum)
+	// strconv最多支持36进制；更大的进制和自定义字母表（如用主键生成短ID）用baseconv包
+	var userID int32 = 2147483647
+	fmt.Printf("主键%d的62进制：%s，Base58：%s\n", userID,
+		baseconv.Base62.EncodeUint64(uint64(userID)), baseconv.Base58.EncodeUint64(uint64(userID)))
 
 	// ===================== 字符串 ↔ 浮点数 =====================
 	fmt.Println("\n=== 字符串 ↔ 浮点数 ===")
@@ -249,6 +285,25 @@ func main() {
 	} else {
 		fmt.Println("转换结果：", badNum)
 	}
+	// 配置文件中的宽松写法（首尾空白、千位分隔符、全角数字、"是/否"）用lenient包解析，
+	// 失败时错误会指出是哪条规则、在第几个字节
+	for _, s := range []string{" 42 ", "1,000", "０１２", "1,00"} {
+		if n, err := lenient.ParseInt(s, 64); err != nil {
+			fmt.Printf("宽松解析失败：%v\n", err)
+		} else {
+			fmt.Printf("宽松解析%q：%d\n", s, n)
+		}
+	}
+	yes, _ := lenient.ParseBool("是")
+	fmt.Printf("宽松解析\"是\"：%t\n", yes)
+	// convert包的错误带有出错位置和修改建议，用errors.As取出后可以给用户展示友好的提示
+	_, err = convert.Int(badStr1)
+	var convErr *convert.Error
+	if errors.As(err, &convErr) {
+		fmt.Printf("%v\n%s\n", convErr, convErr.Pointer())
+		fmt.Printf("出错位置：第%d个字符（字节下标%d），目标类型：%s，%s，建议：%s\n\n",
+			convErr.RuneOffset+1, convErr.Offset, convErr.Type, convErr.Kind, convErr.Suggestion)
+	}
 
 	// ===================== 高性能拼接 =====================
 	fmt.Println("=== 高性能拼接 ===")
@@ -258,13 +313,15 @@ func main() {
 		badStr2 += "a" // 性能差！
 	}
 
-	// 正例：用strings.Builder（内存复用，性能提升10倍+）
+	// 正例：用strings.Builder（内存复用，实测见下表）
 	var builder1 strings.Builder
 	for i := 0; i < 1000; i++ {
 		builder1.WriteByte('a')
 	}
 	goodStr := builder1.String()
 	fmt.Printf("Builder拼接结果长度：%d\n\n", len(goodStr))
+	// 各种拼接方式的实测对比，由 go generate ./concat 运行基准测试生成
+	fmt.Println(concatBench)
 
 	// ===================== 中文编码兼容 =====================
 	fmt.Println("=== 中文编码兼容 ===")
@@ -279,7 +336,10 @@ func main() {
 	runeSlice1 := []rune(chineseStr)
 	// 截取前3个字符（Go语）
 	chineseSub := string(runeSlice1[:3])
-	fmt.Printf("截取前3个中文字符：%s\n\n", chineseSub)
+	fmt.Printf("截取前3个中文字符：%s\n", chineseSub)
+	// 进阶：[]rune 需要为整个字符串分配内存，strutil 直接按UTF-8逐字符前进，返回原字符串的子串
+	fmt.Printf("strutil.Left截取前3个字符：%s\n", strutil.Left(chineseStr, 3))
+	fmt.Printf("strutil.Right截取后2个字符：%s\n\n", strutil.Right(chineseStr, 2))
 
 	// ===================== 空字符串判断 =====================
 	fmt.Println("=== 空字符串判断 ===")
@@ -302,3 +362,46 @@ func main() {
 		fmt.Println("空格字符串不为空（需用TrimSpace处理）")
 	}
 }
+
+// ===================== 字节、字符（rune）与字素簇 =====================
+// len()统计字节数，for range/utf8.RuneCountInString统计rune数，
+// 但用户眼中的“一个字”是字素簇（grapheme cluster），可能由多个rune组成：
+// 组合重音（e + U+0301）、emoji肤色修饰、国旗（两个区域指示符）、用零宽连接符拼成的家庭emoji
+func stringSection2() {
+	fmt.Println("=== 字节数、rune数与字素簇数 ===")
+	samples := []struct {
+		desc string
+		s    string
+	}{
+		{"中文", "Go语言"},
+		{"组合重音", "cafe\u0301"},                                    // café，é由e和组合重音两个rune组成
+		{"肤色修饰", "\U0001F44D\U0001F3FD"},                          // 👍🏽
+		{"国旗", "\U0001F1E8\U0001F1F3"},                            // 🇨🇳
+		{"家庭emoji", "\U0001F468\u200D\U0001F469\u200D\U0001F467"}, // 👨‍👩‍👧
+	}
+	for _, sample := range samples {
+		fmt.Printf("%s %s：字节数 %d，rune数 %d，字素簇数 %d\n",
+			sample.desc, sample.s, len(sample.s), strutil.RuneLen(sample.s), grapheme.Count(sample.s))
+	}
+
+	// 按字素簇遍历
+	fmt.Println("\n=== 按字素簇遍历 ===")
+	mixed := "Hi\U0001F1E8\U0001F1F3cafe\u0301"
+	for g := range grapheme.Graphemes(mixed) {
+		fmt.Printf("[%s]（%d个rune）", g, strutil.RuneLen(g))
+	}
+	fmt.Println()
+
+	// 反转字符串：按rune反转会把组合重音挪到别的字母上、把国旗拆开
+	fmt.Println("\n=== 反转字符串 ===")
+	runes := []rune(mixed)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	fmt.Printf("按rune反转（%+q）：错误\n", string(runes))
+	fmt.Printf("按字素簇反转（%+q）：正确\n", grapheme.Reverse(mixed))
+
+	// 截取：按字素簇截取不会切开emoji
+	sub, _ := grapheme.Substring(mixed, 2, 3)
+	fmt.Printf("\n截取第3个字素簇：%s\n", sub)
+}
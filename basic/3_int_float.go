@@ -1,15 +1,39 @@
-package main
+package basic
 
 import (
 	"fmt"
 	"math"
+	"runtime"
 	"unsafe"
+
+	"github.com/colayear/go_learning/checked"
+	"github.com/colayear/go_learning/cnnum"
+	"github.com/colayear/go_learning/decimal"
+	"github.com/colayear/go_learning/floatcmp"
+	"github.com/colayear/go_learning/ieee754"
+	"github.com/colayear/go_learning/lesson"
+	"github.com/colayear/go_learning/money"
+	"github.com/colayear/go_learning/numtype"
 )
 
-func main() {
+func init() {
+	lesson.Register(lesson.Lesson{
+		ID:    "3_int_float",
+		Title: "整数与浮点数",
+		Sections: []lesson.Section{
+			{ID: "numberSection1", Title: "整数、浮点数与进制", Run: numberSection1},
+			{ID: "numberSection2", Title: "整数溢出与平台相关的 int", Run: numberSection2},
+			{ID: "numberSection3", Title: "浮点数的精确值与比较", Run: numberSection3},
+			{ID: "numberSection4", Title: "十进制小数与金额", Run: numberSection4},
+			{ID: "numberSection5", Title: "中文数字", Run: numberSection5},
+		},
+	})
+}
+
+func numberSection1() {
 	// ===================== 1. 有符号整数（可表示正数、负数、0） =====================
 	// int8: 占1字节，范围 -128 ~ 127
-	// var num8Err int8 = 128 // 报错：constant 128 overflows int8
+	// var num8Err int8 = 128 // 报错：cannot use 128 (untyped int constant) as int8 value in variable declaration (overflows)
 	// int16: 占2字节，范围 -32768 ~ 32767
 	// int32: 占4字节，范围 -2147483648 ~ 2147483647（常用作整型ID）
 	// int64: 占8字节，范围 -9223372036854775808 ~ 9223372036854775807
@@ -78,7 +102,7 @@ func main() {
 	// 正确写法：判断差值是否小于极小值（如1e-9）
 	// 1e-9 是工程中常用的“精度阈值”，可根据场景调整（如1e-6、1e-12）
 	if math.Abs(sum-c) < 1e-9 {
-		fmt.Println("sum 和 c 实际相等（差值 < 1e-9）\n")
+		fmt.Print("sum 和 c 实际相等（差值 < 1e-9）\n\n")
 	}
 	// 金融场景示例：用整型存储金额（分）
 	var amountCent int64 = 1001 // 10.01元
@@ -121,3 +145,82 @@ func main() {
 	var redColor int = 0xFF0000
 	fmt.Printf("红色值 0xFF0000 十进制：%d，十六进制：%X\n", redColor, redColor)
 }
+
+func numberSection2() {
+	fmt.Println("=== 运行时的整数溢出 ===")
+	// 常量溢出在编译期报错，运行时的溢出却不会报错，而是悄悄回绕：127 + 1 变成 -128
+	// checked 包检查溢出（ok 为 false），或者饱和到该类型的最大值、最小值
+	var num8 int8 = 127
+	sum8, ok8 := checked.AddChecked(num8, 1)
+	fmt.Printf("num8 + 1 = %d；checked.AddChecked = %d, %t；checked.AddSaturated = %d\n",
+		num8+1, sum8, ok8, checked.AddSaturated(num8, 1))
+	var num64 int64 = -9223372036854775808
+	id32, okID := checked.Convert[int32](num64)
+	fmt.Printf("类型转换溢出：int32(num64) = %d；checked.Convert[int32] = %d, %t\n", int32(num64), id32, okID)
+
+	fmt.Println("\n=== int 的大小随平台变化 ===")
+	// 在 64 位机器上看不到 4 字节的 int：golearn arch 3_int_float 把本课交叉编译为 GOARCH=386 运行，并排对比两份输出
+	// numtype 包在运行时从类型本身读出大小和范围，命名类型同样适用；当前平台的完整表格：golearn types
+	type UserID int32
+	intInfo, idInfo := numtype.For[int](), numtype.For[UserID]()
+	fmt.Printf("int 在 %s 上占 %d 字节，范围 %v ~ %v\n", runtime.GOARCH, intInfo.Size, intInfo.Min, intInfo.Max)
+	fmt.Printf("UserID 的底层类型为 %s，最大值 %v，能否放下 1<<40：%t\n", idInfo.Kind, idInfo.Max, idInfo.Fits(int64(1<<40)))
+	fmt.Println()
+}
+
+func numberSection3() {
+	var a, b, c float64 = 0.1, 0.2, 0.3
+	sum := a + b
+	fmt.Println("=== 浮点数实际存储的值 ===")
+	// %f 只显示 6 位小数，看不出差别。0.1 在二进制中是无限循环小数，只能存储最接近的可表示数，
+	// ieee754.Exact 显示实际存储的精确值（完整的拆解过程见 golearn float 0.1+0.2）
+	fmt.Printf("0.1 实际存储为 %s\n", ieee754.Exact(a))
+	fmt.Printf("0.1 + 0.2 实际为 %s\n", ieee754.Exact(sum))
+	fmt.Printf("0.3 实际存储为 %s\n", ieee754.Exact(c))
+
+	fmt.Println("\n=== 与量级无关的浮点数比较 ===")
+	// 固定的 1e-9 阈值对科学计数法的例子并不适用：1.23e9 与紧挨着它的下一个 float64 相差约 2.4e-7，
+	// 被判断为不相等；而 4.56e-6 与 4.57e-6 相差千分之二，差值却小于 1e-6 这样放宽后的阈值。
+	// floatcmp 包按相对误差和 ULP 距离（中间隔着几个可表示的浮点数）比较，与数的量级无关
+	var bigNum, smallNum float64 = 1.23e9, 4.56e-6
+	nextBig := math.Nextafter(bigNum, math.Inf(1))
+	fmt.Printf("1.23e9 与下一个 float64：差值 < 1e-9：%t，floatcmp.AlmostEqual：%t，ULP 距离：%d\n",
+		math.Abs(bigNum-nextBig) < 1e-9, floatcmp.AlmostEqual(bigNum, nextBig), floatcmp.ULPDistance(bigNum, nextBig))
+	fmt.Printf("4.56e-6 与 4.57e-6：差值 < 1e-6：%t，floatcmp.AlmostEqual：%t\n",
+		math.Abs(smallNum-4.57e-6) < 1e-6, floatcmp.AlmostEqual(smallNum, 4.57e-6))
+	fmt.Printf("0.1 + 0.2 与 0.3：floatcmp.AlmostEqual：%t，ULP 距离：%d\n", floatcmp.AlmostEqual(sum, c), floatcmp.ULPDistance(sum, c))
+	fmt.Println()
+}
+
+func numberSection4() {
+	fmt.Println("=== 十进制小数 ===")
+	// 根本的解决办法：用十进制小数计算。decimal 包按字面值精确解析 "0.1"，0.1 + 0.2 就是 0.3
+	decSum := decimal.MustParse("0.1").Add(decimal.MustParse("0.2"))
+	fmt.Printf("0.1 + 0.2 = %s，等于 0.3：%t\n", decSum, decSum.Equal(decimal.MustParse("0.3")))
+	// 利率这类小数位数多的运算，用 Context 统一保留位数和舍入方式
+	ctx := decimal.Context{Scale: 12, Mode: decimal.HalfEven}
+	dailyRate, _ := ctx.Quo(decimal.MustParse("0.0375"), decimal.New(365, 0))
+	fmt.Printf("年利率 0.0375 的日利率（保留 12 位）= %s\n", dailyRate)
+
+	fmt.Println("\n=== 金额 ===")
+	var amountCent int64 = 1001 // 10.01元
+	// 发票、支票上的大写金额直接从分计算，不经过浮点数
+	fmt.Printf("大写金额：%s\n", cnnum.AmountOptions{Prefix: "人民币"}.Format(amountCent))
+	// 分转元时的 float64(amountCent)/100 又把浮点数请了回来，计算税费、分摊时用 money 包：
+	// 金额始终是整数分，乘比例时显式指定舍入方式，拆分后各份之和等于原金额
+	price := money.MustNew(amountCent, "CNY")
+	tax, _ := price.Mul("0.06", decimal.HalfEven)
+	parts, _ := price.Allocate(3)
+	fmt.Printf("%s 的 6%% 税费 = %s（%s），三人分摊 = %v\n", price.Format(), tax, decimal.HalfEven, parts)
+	fmt.Println()
+}
+
+func numberSection5() {
+	fmt.Println("=== 中文数字 ===")
+	// 业务数据中的“两万零五”“1.2亿”，用 cnnum 包解析和格式化
+	cnNum, _ := cnnum.Parse("两万零五")
+	yiNum, _ := cnnum.Parse("1.2亿")
+	fmt.Printf("\"两万零五\" = %d，\"1.2亿\" = %d\n", cnNum, yiNum)
+	fmt.Printf("%d 读作：%s，缩写：%s\n", int64(12300), cnnum.Format(12300), cnnum.Abbrev(12300, 2))
+	fmt.Println()
+}
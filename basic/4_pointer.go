@@ -1,14 +1,22 @@
-package main
+package basic
 
 import (
 	"fmt"
 	"time"
+
+	"github.com/colayear/go_learning/lesson"
 )
 
-func main() {
-	ptrSection1()
-	ptrSection2()
-	ptrSection3()
+func init() {
+	lesson.Register(lesson.Lesson{
+		ID:    "4_pointer",
+		Title: "指针",
+		Sections: []lesson.Section{
+			{ID: "ptrSection1", Title: "指针基本知识", Run: ptrSection1},
+			{ID: "ptrSection2", Title: "值传递与指针传递", Run: ptrSection2},
+			{ID: "ptrSection3", Title: "指针传递与值传递的性能测试", Run: ptrSection3},
+		},
+	})
 }
 
 func ptrSection1() {
@@ -94,7 +102,10 @@ type BigStruct struct {
 	Data [1024 * 1024]int
 }
 
-// 性能：每次调用需拷贝4MB+数据，耗时且占内存
+// 性能：每次调用需拷贝8MB+数据，耗时且占内存
+// go:noinline 防止内联后编译器省掉拷贝，保证和指针传递的对比公平
+//
+//go:noinline
 func processByValue(bs BigStruct) {
 	bs.Age = 30
 	// 模拟业务处理（放大耗时差异）
@@ -104,6 +115,8 @@ func processByValue(bs BigStruct) {
 }
 
 // 性能：仅拷贝8字节地址，所有操作直接作用于原内存，无额外拷贝
+//
+//go:noinline
 func processByPointer(bs *BigStruct) {
 	bs.Age = 30
 	for i := 0; i < len(bs.Data); i++ {
@@ -111,6 +124,23 @@ func processByPointer(bs *BigStruct) {
 	}
 }
 
+// 小类型：值传递拷贝8字节的值，指针传递拷贝8字节的地址，使用时还要再解引用一次
+//
+//go:noinline
+func addOneByValue(x int) int {
+	return x + 1
+}
+
+//go:noinline
+func addOneByPointer(x *int) int {
+	return *x + 1
+}
+
+// ========== 性能测试 ==========
+// 这里只用 time.Since 粗略计时：循环次数固定、没有预热，结果受机器负载影响，仅用于直观感受差距。
+// 被测函数都标了 go:noinline，调用不会被内联后当作死代码删除。
+// 更可靠的数字请看 4_pointer_test.go 中的基准测试：go test ./basic -run '^$' -bench . -benchmem
+
 func testPerformance() {
 
 	var bigData0 BigStruct
@@ -142,27 +172,38 @@ func testPerformance() {
 	durationPointer := time.Since(startPointer)
 
 	fmt.Printf("【性能对比】测试次数：%d次\n", testCount)
-	fmt.Printf("值传递总耗时：%v\n", durationValue)
-	fmt.Printf("指针传递总耗时：%v\n", durationPointer)
+	fmt.Printf("值传递每次耗时：%s\n", perOp(durationValue, testCount))
+	fmt.Printf("指针传递每次耗时：%s\n", perOp(durationPointer, testCount))
 	fmt.Printf("指针传递比値传递快：%.2f倍\n", float64(durationValue.Nanoseconds())/float64(durationPointer.Nanoseconds()))
 }
 
 func testSmallType(val int, ptr *int) {
-	count := 100000000
+	count := 10000000
 
 	startVal := time.Now()
 	for i := 0; i < count; i++ {
-		_ = val + 1
+		addOneByValue(val)
 	}
 	durVal := time.Since(startVal)
 
 	startPtr := time.Now()
 	for i := 0; i < count; i++ {
-		_ = *ptr + 1
+		addOneByPointer(ptr)
 	}
 	durPtr := time.Since(startPtr)
+
 	fmt.Printf("测试次数：%v\n", count)
-	fmt.Printf("小类型（int）值传递耗时：%v\n", durVal)
-	fmt.Printf("小类型（int）指针传递耗时：%v\n", durPtr)
+	fmt.Printf("小类型（int）值传递每次耗时：%s\n", perOp(durVal, count))
+	fmt.Printf("小类型（int）指针传递每次耗时：%s\n", perOp(durPtr, count))
 	fmt.Println("小类型优先值传递，大类型优先指针传递")
+	fmt.Println("完整的基准测试（不同大小的结构体）：go test ./basic -run '^$' -bench . -benchmem")
+}
+
+// perOp 每次操作的平均耗时。小类型的耗时只有一两纳秒，time.Duration 会取整，需要保留小数
+func perOp(d time.Duration, n int) string {
+	ns := float64(d.Nanoseconds()) / float64(n)
+	if ns < 1000 {
+		return fmt.Sprintf("%.2fns", ns)
+	}
+	return time.Duration(ns).String()
 }
@@ -0,0 +1,115 @@
+package basic
+
+import (
+	"fmt"
+	"testing"
+)
+
+// sink 接收被测函数的结果，防止调用被当作死代码删除
+var sink int
+
+// 不同大小的“结构体”，从 8 字节到 8MB（与 BigStruct 相当）
+type array interface {
+	[1]int | [8]int | [128]int | [8 << 10]int | [128 << 10]int | [1 << 20]int
+}
+
+// 值传递：调用时拷贝整个数组
+//
+//go:noinline
+func readByValue[A array](a A) int {
+	return a[0] + a[len(a)-1]
+}
+
+// 指针传递：调用时只拷贝8字节地址
+//
+//go:noinline
+func readByPointer[A array](a *A) int {
+	return (*a)[0] + (*a)[len(*a)-1]
+}
+
+func benchSize[A array](b *testing.B) {
+	a := new(A)
+	name := sizeName(len(*a) * 8)
+	b.Run(name+"/value", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sink = readByValue(*a)
+		}
+	})
+	b.Run(name+"/pointer", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sink = readByPointer(a)
+		}
+	})
+}
+
+// BenchmarkPassBySize 只比较传参本身的开销：值传递的耗时随大小线性增长，指针传递不变
+func BenchmarkPassBySize(b *testing.B) {
+	benchSize[[1]int](b)
+	benchSize[[8]int](b)
+	benchSize[[128]int](b)
+	benchSize[[8 << 10]int](b)
+	benchSize[[128 << 10]int](b)
+	benchSize[[1 << 20]int](b)
+}
+
+// BenchmarkProcessBigStruct 对应 ptrSection3 中的 processByValue / processByPointer
+func BenchmarkProcessBigStruct(b *testing.B) {
+	bs := new(BigStruct)
+	b.Run("value", benchProcessByValue(bs))
+	b.Run("pointer", benchProcessByPointer(bs))
+}
+
+// BenchmarkSmallType 对应 ptrSection3 中的 testSmallType：int 这样的小类型值传递不比指针慢
+func BenchmarkSmallType(b *testing.B) {
+	num := 10
+	b.Run("value", benchAddOneByValue(num))
+	b.Run("pointer", benchAddOneByPointer(&num))
+}
+
+func benchProcessByValue(bs *BigStruct) func(b *testing.B) {
+	return func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			processByValue(*bs)
+		}
+	}
+}
+
+func benchProcessByPointer(bs *BigStruct) func(b *testing.B) {
+	return func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			processByPointer(bs)
+		}
+	}
+}
+
+func benchAddOneByValue(val int) func(b *testing.B) {
+	return func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sink = addOneByValue(val)
+		}
+	}
+}
+
+func benchAddOneByPointer(ptr *int) func(b *testing.B) {
+	return func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sink = addOneByPointer(ptr)
+		}
+	}
+}
+
+func sizeName(n int) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%dMB", n>>20)
+	case n >= 1<<10:
+		return fmt.Sprintf("%dKB", n>>10)
+	}
+	return fmt.Sprintf("%dB", n)
+}
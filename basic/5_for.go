@@ -1,11 +1,22 @@
-package main
+package basic
 
-import "fmt"
+import (
+	"fmt"
 
-func main() {
-	forSection1()
-	forSection2()
+	"github.com/colayear/go_learning/lesson"
+)
+
+func init() {
+	lesson.Register(lesson.Lesson{
+		ID:    "5_for",
+		Title: "循环",
+		Sections: []lesson.Section{
+			{ID: "forSection1", Title: "循环的写法", Run: forSection1},
+			{ID: "forSection2", Title: "循环的终止、退出", Run: forSection2},
+		},
+	})
 }
+
 func forSection1() {
 	fmt.Println("===循环的写法===")
 	// 普通写法
@@ -1,11 +1,21 @@
-package main
-
-import "fmt"
-
-func main() {
-	functionSection1()
-	functionSection2()
-	functionSection3()
+package basic
+
+import (
+	"fmt"
+
+	"github.com/colayear/go_learning/lesson"
+)
+
+func init() {
+	lesson.Register(lesson.Lesson{
+		ID:    "6_function",
+		Title: "函数",
+		Sections: []lesson.Section{
+			{ID: "functionSection1", Title: "匿名函数", Run: functionSection1},
+			{ID: "functionSection2", Title: "高阶函数", Run: functionSection2},
+			{ID: "functionSection3", Title: "闭包函数", Run: functionSection3},
+		},
+	})
 }
 
 // 匿名函数：没有函数名的函数，是 “一次性 / 临时性” 的函数，无法单独定义，只能 “定义时调用” 或 “赋值给变量后调用
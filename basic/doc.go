@@ -0,0 +1,4 @@
+// Package basic 是 Go 语言基础课程。
+// 每个文件是一节课，在 init 中通过 lesson.Register 注册自己及其小节，
+// 小节函数（如 ptrSection1、forSection2）可以单独运行。
+package basic
@@ -0,0 +1,69 @@
+package basic
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/colayear/go_learning/lesson"
+)
+
+var update = flag.Bool("update", false, "用当前输出重新生成 testdata/golden 下的 golden 文件")
+
+// archMasks 屏蔽随 GOARCH 变化的输出（int、uint 和字符串头的大小，numtype 读出的 int 范围），
+// 让同一份 golden 文件在 64 位和 32 位平台上都能通过（GOARCH=386 go test ./basic）。
+// 这些内容不放进 lesson.Normalize：golearn arch 正是要对比它们在两种架构上的差别。
+var archMasks = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(`(?m)^(u?int 类型 - 值：\d+，占用字节：)\d+`), "${1}<平台相关>"},
+	{regexp.MustCompile(`(?m)^(占用内存大小：)\d+`), "${1}<平台相关>"},
+	{regexp.MustCompile(`(?m)^int 在 \S+ 上占 \d+ 字节，范围 \S+ ~ \S+$`), "int 在 <GOARCH> 上占 <平台相关> 字节，范围 <平台相关>"},
+}
+
+func normalize(out string) []byte {
+	out = lesson.Normalize(out)
+	for _, m := range archMasks {
+		out = m.re.ReplaceAllString(out, m.repl)
+	}
+	return []byte(out)
+}
+
+// TestGolden 运行每个小节，把标准输出经 normalize 屏蔽内存地址、耗时和平台相关的内容后，与 testdata/golden/<课程>/<小节>.golden 比对。
+// 修改课程后用 go test ./basic -run TestGolden -update 重新生成。
+func TestGolden(t *testing.T) {
+	for _, l := range lesson.All() {
+		for i := range l.Sections {
+			s := &l.Sections[i]
+			t.Run(l.ID+"/"+s.ID, func(t *testing.T) {
+				var buf bytes.Buffer
+				if err := lesson.Exec(s, &buf); err != nil {
+					t.Fatal(err)
+				}
+				got := normalize(buf.String())
+
+				path := filepath.Join("testdata", "golden", l.ID, s.ID+".golden")
+				if *update {
+					if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(path, got, 0o644); err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+				want, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatalf("%v（首次运行请加 -update 生成）", err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("输出与 %s 不一致\n--- 实际输出 ---\n%s\n--- 期望输出 ---\n%s", path, got, want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Package checked 检查溢出的整数运算，适用于所有整数类型（包括以整数为底层类型的命名类型）。
+//
+// 3_int_float.go 中 var num8Err int8 = 128 编译报错，但运行时 num8 + 1 不会报错，
+// 而是悄悄回绕为 -128。本包提供三组函数：
+//
+//   - AddChecked、SubChecked、MulChecked、DivChecked 返回 (结果, ok)，溢出时 ok 为 false
+//   - AddSaturated 等饱和运算，溢出时取该类型的最大值或最小值，如 int8 的 127 + 1 = 127
+//   - Convert、ConvertSaturated 检查整数类型之间的转换，如 int64 → int32、int → uint8
+package checked
+
+import "unsafe"
+
+// Integer 所有底层类型为整数的类型
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Signed 是否为有符号整数类型
+func Signed[T Integer]() bool {
+	var zero T
+	return zero-1 < 0
+}
+
+// Bits 类型的位数，如 int8 为 8；int、uint、uintptr 随平台为 32 或 64
+func Bits[T Integer]() int {
+	var zero T
+	return int(unsafe.Sizeof(zero)) * 8
+}
+
+// minusOne 有符号类型的 -1。T 的类型集中有无符号类型，不能直接写 T(-1)
+func minusOne[T Integer]() T {
+	var zero T
+	return zero - 1
+}
+
+// MaxValue 类型的最大值，如 int8 为 127、uint16 为 65535
+func MaxValue[T Integer]() T {
+	if Signed[T]() {
+		return T(1)<<(Bits[T]()-1) - 1 // 1<<(n-1) 回绕为最小值，再减 1 回绕为最大值
+	}
+	var zero T
+	return ^zero
+}
+
+// MinValue 类型的最小值，如 int8 为 -128，无符号类型为 0
+func MinValue[T Integer]() T {
+	if Signed[T]() {
+		return -MaxValue[T]() - 1
+	}
+	return 0
+}
+
+// AddChecked a + b；溢出时 ok 为 false，result 为回绕后的值（与直接写 a + b 相同）
+func AddChecked[T Integer](a, b T) (result T, ok bool) {
+	c := a + b
+	if Signed[T]() {
+		// b > 0 时结果应当变大，b < 0 时应当变小
+		return c, b == 0 || (c > a) == (b > 0)
+	}
+	return c, c >= a
+}
+
+// SubChecked a - b；溢出时 ok 为 false，result 为回绕后的值
+func SubChecked[T Integer](a, b T) (result T, ok bool) {
+	c := a - b
+	if Signed[T]() {
+		return c, b == 0 || (c < a) == (b > 0)
+	}
+	return c, a >= b
+}
+
+// MulChecked a × b；溢出时 ok 为 false，result 为回绕后的值
+func MulChecked[T Integer](a, b T) (result T, ok bool) {
+	c := a * b
+	if a == 0 || b == 0 {
+		return c, true
+	}
+	// 最小值 × -1 溢出后仍是最小值，c / b == a 检查不出来，需要单独判断
+	if Signed[T]() && (a == minusOne[T]() && b == MinValue[T]() || b == minusOne[T]() && a == MinValue[T]()) {
+		return c, false
+	}
+	return c, c/b == a
+}
+
+// DivChecked a / b（向 0 截断）；b 为 0 时返回 (0, false) 而不是 panic；
+// 有符号类型的最小值 / -1 溢出，返回 (最小值, false)
+func DivChecked[T Integer](a, b T) (result T, ok bool) {
+	if b == 0 {
+		return 0, false
+	}
+	if Signed[T]() && b == minusOne[T]() && a == MinValue[T]() {
+		return a, false
+	}
+	return a / b, true
+}
+
+// AddSaturated a + b，溢出时取最大值或最小值
+func AddSaturated[T Integer](a, b T) T {
+	c, ok := AddChecked(a, b)
+	switch {
+	case ok:
+		return c
+	case Signed[T]() && b < 0:
+		return MinValue[T]()
+	}
+	return MaxValue[T]()
+}
+
+// SubSaturated a - b，溢出时取最大值或最小值，无符号类型 1 - 2 = 0
+func SubSaturated[T Integer](a, b T) T {
+	c, ok := SubChecked(a, b)
+	switch {
+	case ok:
+		return c
+	case Signed[T]() && b < 0:
+		return MaxValue[T]()
+	}
+	return MinValue[T]()
+}
+
+// MulSaturated a × b，溢出时按结果的符号取最大值或最小值
+func MulSaturated[T Integer](a, b T) T {
+	c, ok := MulChecked(a, b)
+	switch {
+	case ok:
+		return c
+	case (a < 0) != (b < 0):
+		return MinValue[T]()
+	}
+	return MaxValue[T]()
+}
+
+// DivSaturated a / b，有符号类型的最小值 / -1 取最大值。与 a / b 相同，b 为 0 时 panic。
+func DivSaturated[T Integer](a, b T) T {
+	if Signed[T]() && b == minusOne[T]() && a == MinValue[T]() {
+		return MaxValue[T]()
+	}
+	return a / b
+}
+
+// Convert 把 v 转换为 To 类型；超出 To 的范围时 ok 为 false，result 为直接转换 To(v) 的结果。
+//
+//	Convert[int32](int64(1 << 40))  // 0, false
+//	Convert[uint8](-1)              // 255, false
+func Convert[To, From Integer](v From) (result To, ok bool) {
+	t := To(v)
+	// 转换回去不变，并且符号没有改变（int8(-1) → uint8 为 255，转换回去仍是 -1）
+	return t, From(t) == v && (t < 0) == (v < 0)
+}
+
+// ConvertSaturated 把 v 转换为 To 类型，超出范围时取 To 的最大值或最小值，如 int 的 300 → uint8 为 255
+func ConvertSaturated[To, From Integer](v From) To {
+	t, ok := Convert[To](v)
+	switch {
+	case ok:
+		return t
+	case v < 0:
+		return MinValue[To]()
+	}
+	return MaxValue[To]()
+}
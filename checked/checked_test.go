@@ -0,0 +1,209 @@
+package checked
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+type celsius int16
+
+func TestLimits(t *testing.T) {
+	if MaxValue[int8]() != math.MaxInt8 || MinValue[int8]() != math.MinInt8 ||
+		MaxValue[int64]() != math.MaxInt64 || MinValue[int64]() != math.MinInt64 ||
+		MaxValue[uint32]() != math.MaxUint32 || MinValue[uint]() != 0 ||
+		MaxValue[celsius]() != math.MaxInt16 || MinValue[celsius]() != math.MinInt16 {
+		t.Error("最大值、最小值不正确")
+	}
+	if !Signed[celsius]() || Signed[uintptr]() || Bits[celsius]() != 16 || Bits[int]() != 32<<(^uint(0)>>63) {
+		t.Error("Signed 或 Bits 不正确")
+	}
+}
+
+func TestExamples(t *testing.T) {
+	var num8 int8 = 127
+	if r, ok := AddChecked(num8, 1); ok || r != -128 {
+		t.Errorf("127 + 1 = %d, %t", r, ok)
+	}
+	if r := AddSaturated(num8, 1); r != 127 {
+		t.Errorf("饱和 127 + 1 = %d", r)
+	}
+	if r := SubSaturated(uint8(1), 2); r != 0 {
+		t.Errorf("饱和 uint8 1 - 2 = %d", r)
+	}
+	if r := MulSaturated(int8(-64), 3); r != -128 {
+		t.Errorf("饱和 -64 × 3 = %d", r)
+	}
+	if r, ok := DivChecked(int8(-128), -1); ok || r != -128 {
+		t.Errorf("-128 / -1 = %d, %t", r, ok)
+	}
+	if r, ok := DivChecked(5, 0); ok || r != 0 {
+		t.Errorf("5 / 0 = %d, %t", r, ok)
+	}
+	if r := DivSaturated(int8(-128), -1); r != 127 {
+		t.Errorf("饱和 -128 / -1 = %d", r)
+	}
+	if r, ok := Convert[int32](int64(1 << 40)); ok || r != 0 {
+		t.Errorf("int64 1<<40 → int32 = %d, %t", r, ok)
+	}
+	if r, ok := Convert[uint8](-1); ok || r != 255 {
+		t.Errorf("int -1 → uint8 = %d, %t", r, ok)
+	}
+	if r, ok := Convert[int64](uint64(math.MaxUint64)); ok || r != -1 {
+		t.Errorf("uint64 最大值 → int64 = %d, %t", r, ok)
+	}
+	if r, ok := Convert[celsius](int64(-300)); !ok || r != -300 {
+		t.Errorf("int64 -300 → celsius = %d, %t", r, ok)
+	}
+	if ConvertSaturated[uint8](300) != 255 || ConvertSaturated[uint8](-5) != 0 || ConvertSaturated[int8](int64(math.MinInt64)) != -128 {
+		t.Error("ConvertSaturated 不正确")
+	}
+}
+
+func toBig[T Integer](v T) *big.Int {
+	if Signed[T]() {
+		return big.NewInt(int64(v))
+	}
+	return new(big.Int).SetUint64(uint64(v))
+}
+
+// fits big.Int 能否用 T 表示；能表示时返回对应的值
+func fits[T Integer](n *big.Int) (T, bool) {
+	if n.Cmp(toBig(MinValue[T]())) < 0 || n.Cmp(toBig(MaxValue[T]())) > 0 {
+		return 0, false
+	}
+	if Signed[T]() {
+		return T(n.Int64()), true
+	}
+	return T(n.Uint64()), true
+}
+
+// saturate 把 n 限制在 T 的范围内
+func saturate[T Integer](n *big.Int) T {
+	if v, ok := fits[T](n); ok {
+		return v
+	}
+	if n.Sign() < 0 {
+		return MinValue[T]()
+	}
+	return MaxValue[T]()
+}
+
+// checkArith 用 math/big 计算精确结果，与检查溢出的运算和饱和运算对照
+func checkArith[T Integer](t *testing.T, a, b T) {
+	t.Helper()
+	x, y := toBig(a), toBig(b)
+	for _, tt := range []struct {
+		name    string
+		exact   *big.Int
+		checked func(T, T) (T, bool)
+		sat     func(T, T) T
+		wrapped T
+	}{
+		{"+", new(big.Int).Add(x, y), AddChecked[T], AddSaturated[T], a + b},
+		{"-", new(big.Int).Sub(x, y), SubChecked[T], SubSaturated[T], a - b},
+		{"×", new(big.Int).Mul(x, y), MulChecked[T], MulSaturated[T], a * b},
+	} {
+		want, fit := fits[T](tt.exact)
+		got, ok := tt.checked(a, b)
+		if ok != fit || got != tt.wrapped || fit && got != want {
+			t.Fatalf("%T: %d %s %d = %d, %t；精确结果 %s", a, a, tt.name, b, got, ok, tt.exact)
+		}
+		if s := tt.sat(a, b); s != saturate[T](tt.exact) {
+			t.Fatalf("%T: 饱和 %d %s %d = %d；精确结果 %s", a, a, tt.name, b, s, tt.exact)
+		}
+	}
+	if b == 0 {
+		if got, ok := DivChecked(a, b); ok || got != 0 {
+			t.Fatalf("%T: %d / 0 = %d, %t", a, a, got, ok)
+		}
+		return
+	}
+	exact := new(big.Int).Quo(x, y) // 向 0 截断，与 Go 的 / 相同
+	want, fit := fits[T](exact)
+	if got, ok := DivChecked(a, b); ok != fit || fit && got != want {
+		t.Fatalf("%T: %d / %d = %d, %t；精确结果 %s", a, a, b, got, ok, exact)
+	}
+	if s := DivSaturated(a, b); s != saturate[T](exact) {
+		t.Fatalf("%T: 饱和 %d / %d = %d；精确结果 %s", a, a, b, s, exact)
+	}
+}
+
+// checkConvert 把 v 转换为 To，与 math/big 的判断对照
+func checkConvert[To, From Integer](t *testing.T, v From) {
+	t.Helper()
+	n := toBig(v)
+	want, fit := fits[To](n)
+	got, ok := Convert[To](v)
+	if ok != fit || got != To(v) || fit && got != want {
+		t.Fatalf("%T %d → %T = %d, %t", v, v, got, got, ok)
+	}
+	if s := ConvertSaturated[To](v); s != saturate[To](n) {
+		t.Fatalf("%T %d → %T 饱和转换 = %d", v, v, s, s)
+	}
+}
+
+func convertAll[From Integer](t *testing.T, v From) {
+	t.Helper()
+	checkConvert[int8](t, v)
+	checkConvert[int16](t, v)
+	checkConvert[int32](t, v)
+	checkConvert[int64](t, v)
+	checkConvert[int](t, v)
+	checkConvert[uint8](t, v)
+	checkConvert[uint16](t, v)
+	checkConvert[uint32](t, v)
+	checkConvert[uint64](t, v)
+	checkConvert[uint](t, v)
+	checkConvert[uintptr](t, v)
+	checkConvert[celsius](t, v)
+}
+
+var seeds = []uint64{
+	0, 1, 2, 3, 0x7F, 0x80, 0xFF, 0x100, 0x7FFF, 0x8000, 0xFFFF, 0x7FFFFFFF, 0x80000000, 0xFFFFFFFF,
+	1 << 32, math.MaxInt64, 1 << 63, math.MaxUint64, math.MaxUint64 - 1, 1<<63 + 1,
+}
+
+func FuzzArith(f *testing.F) {
+	for _, a := range seeds {
+		for _, b := range seeds {
+			f.Add(a, b)
+		}
+	}
+	f.Fuzz(func(t *testing.T, a, b uint64) {
+		// 同一组位模式截断为各种类型
+		checkArith(t, int8(a), int8(b))
+		checkArith(t, int16(a), int16(b))
+		checkArith(t, int32(a), int32(b))
+		checkArith(t, int64(a), int64(b))
+		checkArith(t, int(a), int(b))
+		checkArith(t, uint8(a), uint8(b))
+		checkArith(t, uint16(a), uint16(b))
+		checkArith(t, uint32(a), uint32(b))
+		checkArith(t, a, b)
+		checkArith(t, uint(a), uint(b))
+		checkArith(t, uintptr(a), uintptr(b))
+		checkArith(t, celsius(a), celsius(b))
+	})
+}
+
+func FuzzConvert(f *testing.F) {
+	for _, v := range seeds {
+		f.Add(v)
+		f.Add(-v)
+	}
+	f.Fuzz(func(t *testing.T, v uint64) {
+		convertAll(t, int8(v))
+		convertAll(t, int16(v))
+		convertAll(t, int32(v))
+		convertAll(t, int64(v))
+		convertAll(t, int(v))
+		convertAll(t, uint8(v))
+		convertAll(t, uint16(v))
+		convertAll(t, uint32(v))
+		convertAll(t, v)
+		convertAll(t, uint(v))
+		convertAll(t, uintptr(v))
+		convertAll(t, celsius(v))
+	})
+}
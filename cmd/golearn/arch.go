@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/colayear/go_learning/crossarch"
+	"github.com/colayear/go_learning/lesson"
+)
+
+// runArch 分别用本机架构和 --goarch 编译运行课程，并排对比两份输出
+func runArch(args []string) error {
+	fs := flag.NewFlagSet("arch", flag.ContinueOnError)
+	goarch := fs.String("goarch", "386", "对比的目标架构，需要能在本机直接运行")
+	width := fs.Int("width", 70, "每侧显示的列数")
+	all := fs.Bool("all", false, "显示全部输出，而不只是不同的行")
+	paths, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("用法：golearn arch [--goarch 386] [--all] <课程[/小节]> ...")
+	}
+	if *width < 1 {
+		return fmt.Errorf("--width 需要是正整数，实际为 %d", *width)
+	}
+	if *goarch == runtime.GOARCH {
+		return fmt.Errorf("目标架构与本机相同（%s），请用 --goarch 指定另一个架构", runtime.GOARCH)
+	}
+	// 先检查所有路径，避免编译两遍之后才发现拼写错误
+	var first *lesson.Lesson
+	for _, p := range paths {
+		l, _, err := lesson.Find(p)
+		if err != nil {
+			return err
+		}
+		if first == nil {
+			first = l
+		}
+	}
+	// 课程都在同一个模块中，运行器在第一节课的目录中编译即可
+	dir, err := sourceDir(first)
+	if err != nil {
+		return err
+	}
+
+	native, err := crossarch.Run(dir, runtime.GOARCH, paths...)
+	if err != nil {
+		return err
+	}
+	cross, err := crossarch.Run(dir, *goarch, paths...)
+	if err != nil {
+		return err
+	}
+	lines := crossarch.Diff(strings.Split(native, "\n"), strings.Split(cross, "\n"))
+	crossarch.WriteSideBySide(os.Stdout, "GOARCH="+runtime.GOARCH, "GOARCH="+*goarch, lines, *width, !*all)
+
+	changed := 0
+	for _, l := range lines {
+		if l.Op != crossarch.Same {
+			changed++
+		}
+	}
+	if changed == 0 {
+		fmt.Printf("\n两种架构的输出完全相同\n")
+	} else {
+		fmt.Printf("\n共 %d 行不同，«» 中为不同的部分（内存地址和耗时已屏蔽）\n", changed)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/colayear/go_learning/escape"
+	"github.com/colayear/go_learning/lesson"
+)
+
+// runEscape 用 -gcflags=-m 编译课程，把逃逸分析和内联诊断标注在课程函数的源码上
+func runEscape(args []string) error {
+	fs := flag.NewFlagSet("escape", flag.ContinueOnError)
+	all := fs.Bool("all", false, "同时显示内联调用点、可变参数未逃逸等琐碎诊断")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		return fmt.Errorf("用法：golearn escape [--all] <课程> [函数 ...]")
+	}
+	l, ok := lesson.Get(rest[0])
+	if !ok {
+		return fmt.Errorf("未知课程 %q", rest[0])
+	}
+	names := rest[1:]
+
+	dir, err := sourceDir(l)
+	if err != nil {
+		return err
+	}
+	diags, err := escape.Build(dir)
+	if err != nil {
+		return err
+	}
+	if !*all {
+		kept := diags[:0]
+		for _, d := range diags {
+			if !d.Noise() {
+				kept = append(kept, d)
+			}
+		}
+		diags = kept
+	}
+	funcs, err := escape.Annotate(l.File, diags)
+	if err != nil {
+		return err
+	}
+	src, err := os.ReadFile(l.File)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(src), "\n")
+
+	counts := map[escape.Kind]int{}
+	shown := 0
+	for _, name := range names {
+		if !hasFunc(funcs, name) {
+			return fmt.Errorf("课程 %s 中没有函数 %q", l.ID, name)
+		}
+	}
+	for _, f := range funcs {
+		if len(names) > 0 && !contains(names, f.Name) || len(names) == 0 && len(f.Diags) == 0 {
+			continue
+		}
+		shown++
+		printFunc(filepath.Base(l.File), f, lines)
+		for _, d := range f.Diags {
+			counts[d.Kind]++
+		}
+	}
+	fmt.Printf("共 %d 个函数：%d 个变量分配到堆，%d 处值逃逸到堆，%d 个函数可内联\n",
+		shown, counts[escape.MovedToHeap], counts[escape.EscapesToHeap], counts[escape.CanInline])
+	return nil
+}
+
+func printFunc(file string, f escape.Func, lines []string) {
+	fmt.Printf("== %s（%s:%d-%d）==\n", f.Name, file, f.StartLine, f.EndLine)
+	byLine := map[int][]escape.Diag{}
+	for _, d := range f.Diags {
+		byLine[d.Line] = append(byLine[d.Line], d)
+	}
+	for n := f.StartLine; n <= f.EndLine && n <= len(lines); n++ {
+		fmt.Printf("%4d │ %s\n", n, strings.ReplaceAll(lines[n-1], "\t", "    "))
+		for _, d := range byLine[n] {
+			fmt.Printf("     │   ↳ [%s] %s（第 %d 列）\n", d.Kind, d.Msg, d.Col)
+		}
+	}
+	fmt.Println()
+}
+
+func hasFunc(funcs []escape.Func, name string) bool {
+	for _, f := range funcs {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
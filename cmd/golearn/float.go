@@ -0,0 +1,137 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/colayear/go_learning/floatcmp"
+	"github.com/colayear/go_learning/ieee754"
+	"github.com/colayear/go_learning/strutil"
+)
+
+// runFloat 拆解浮点数的存储方式，有运算符时再展示这一步运算的舍入误差
+func runFloat(args []string) error {
+	fs := flag.NewFlagSet("float", flag.ContinueOnError)
+	f32 := fs.Bool("32", false, "按 float32 计算（默认 float64）")
+	fs.BoolVar(&fullDigits, "full", false, "完整显示很长的精确值（如非规格化数有数百位小数）")
+	// 负数（-0.5）以 "-" 开头，会被 flag 当成未知参数，因此遇到第一个不是已知参数的参数就停止解析
+	n := 0
+	for n < len(args) && isFlag(fs, args[n]) {
+		n++
+	}
+	if err := fs.Parse(args[:n]); err != nil {
+		return err
+	}
+	rest := append(fs.Args(), args[n:]...)
+	if len(rest) == 0 {
+		return fmt.Errorf("用法：golearn float [--32] [--full] <数或表达式>，如 golearn float 0.1+0.2、golearn float -0.5")
+	}
+	bitSize := 64
+	if *f32 {
+		bitSize = 32
+	}
+	expr := strings.TrimSpace(strings.Join(rest, " "))
+
+	// 单独一个数时只拆解它，否则按表达式计算
+	if f, err := ieee754.ParseFloat(expr, bitSize); err == nil {
+		printFloat(expr, f, bitSize)
+		return nil
+	}
+	op, err := ieee754.ParseOp(expr, bitSize)
+	if err != nil {
+		return err
+	}
+	printFloat(op.XText, op.X, bitSize)
+	printFloat(op.YText, op.Y, bitSize)
+	printFloat(op.String(), op.Result, bitSize)
+
+	fmt.Printf("== %s 的误差从哪里来 ==\n", op)
+	if r := op.Exact(); r != nil {
+		row(20, "两个存储值精确运算", clip(ieee754.RatString(r)))
+		row(20, fmt.Sprintf("舍入为 float%d", bitSize), exact(op.Result))
+		row(20, "运算的舍入误差", signed(op.RoundingError()))
+	}
+	if intended := op.Intended(); intended != nil {
+		row(20, "按字面值精确运算", clip(ieee754.RatString(intended)))
+		row(20, "最终结果的总误差", signed(op.TotalError()))
+		// 本来想算的值自身也要舍入存储，比较运算结果与它相差几个 ULP
+		var want float64
+		if bitSize == 32 {
+			f, _ := intended.Float32()
+			want = float64(f)
+		} else {
+			want, _ = intended.Float64()
+		}
+		row(20, "字面值结果的存储值", fmt.Sprintf("%s，与运算结果相差 %d 个 ULP", exact(want), ulps(op.Result, want, bitSize)))
+	}
+	return nil
+}
+
+func printFloat(label string, f float64, bitSize int) {
+	p := ieee754.Inspect(f, bitSize)
+	fmt.Printf("== %s（float%d，%s）==\n", label, bitSize, p.Class())
+	row(10, "位模式", p.BitString())
+	row(10, "", fmt.Sprintf("符号 %d，指数字段 %d（偏移量 %d），尾数字段 0x%X", p.Sign, p.Exponent, p.Bias(), p.Mantissa))
+	row(10, "公式", p.Formula())
+	row(10, "精确值", exact(p.Float()))
+	if c := p.Class(); c != ieee754.NaN {
+		row(10, "前一个", exact(p.Prev().Float()))
+		row(10, "后一个", exact(p.Next().Float()))
+	}
+	if e := ieee754.LiteralError(label, bitSize); e != nil && e.Sign() != 0 {
+		row(10, "存储误差", fmt.Sprintf("%s（精确值 - %s）", signed(e), label))
+	}
+	fmt.Println()
+}
+
+// fullDigits 为 false 时，精确值从第一个非 0 数字起只显示 clipDigits 位
+var fullDigits bool
+
+const clipDigits = 60
+
+func exact(f float64) string { return clip(ieee754.Exact(f)) }
+
+// clip 截短很长的十进制数：最小的非规格化 float64 的精确值小数点后先有 323 个 0，再有 751 位有效数字
+func clip(s string) string {
+	start := strings.IndexAny(s, "123456789")
+	if fullDigits || strings.HasSuffix(s, "…") || start < 0 {
+		return s
+	}
+	if len(s)-start > clipDigits {
+		s = fmt.Sprintf("%s…（还有 %d 位，--full 显示全部）", s[:start+clipDigits], len(s)-start-clipDigits)
+	}
+	// 小数点后连续的 0 太多时只显示个数
+	if dot := strings.IndexByte(s, '.'); dot >= 0 && dot < start && start-dot-1 > 12 {
+		s = fmt.Sprintf("%s.〈%d 个 0〉%s", s[:dot], start-dot-1, s[start:])
+	}
+	return s
+}
+
+// row 打印一行“标签 值”，标签按显示宽度补齐到 width 列，使各行的值对齐
+func row(width int, label, value string) {
+	fmt.Printf("  %s%s\n", strutil.PadRight(label, width), value)
+}
+
+func signed(r *big.Rat) string {
+	if r == nil {
+		return "无法计算"
+	}
+	return signedString(clip(ieee754.RatString(r)))
+}
+
+func signedString(s string) string {
+	if !strings.HasPrefix(s, "-") && strings.Trim(s, "0.") != "" {
+		return "+" + s
+	}
+	return s
+}
+
+// ulps 两个同精度浮点数之间相隔几个可表示数
+func ulps(a, b float64, bitSize int) uint64 {
+	if bitSize == 32 {
+		return floatcmp.ULPDistance(float32(a), float32(b))
+	}
+	return floatcmp.ULPDistance(a, b)
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/colayear/go_learning/lesson"
+)
+
+func runList(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("list 不接受参数")
+	}
+	for _, l := range lesson.All() {
+		fmt.Printf("%s\t%s\n", l.ID, l.Title)
+		for _, s := range l.Sections {
+			fmt.Printf("  %s/%s\t%s\n", l.ID, s.ID, s.Title)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// golearn 是课程运行器：列出、运行 basic/ 下注册的课程和小节。
+//
+//	golearn list                         列出所有课程及小节
+//	golearn run                          按顺序运行所有课程
+//	golearn run 4_pointer                运行一节课
+//	golearn run 4_pointer/ptrSection2    只运行一个小节
+//	golearn run --quiet                  静默运行，只报告失败
+//	golearn verify                       校验注释中的错误示例确实会编译报错
+//	golearn try 2_string                 交互式取消注释错误示例，与编译器的真实报错并排对照
+//	golearn escape 6_function counter    查看逃逸分析和内联结果（go build -gcflags=-m）
+//	golearn float 0.1+0.2                查看浮点数的存储方式和运算的舍入误差
+//	golearn types                        列出当前 GOARCH 下数值类型的大小、对齐和取值范围
+//	golearn arch 3_int_float             对比课程在本机架构和 GOARCH=386 上的输出
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	_ "github.com/colayear/go_learning/basic"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{"list", "列出所有课程及小节", runList},
+	{"run", "运行课程或小节：run [--quiet] [--debug-slices] [课程[/小节] ...]", runRun},
+	{"verify", "校验注释中的错误示例确实会编译报错：verify [课程 ...]", runVerify},
+	{"try", "取消注释一条错误示例并编译，对照编译器报错：try [--width 60] <课程> [示例序号]", runTry},
+	{"escape", "显示课程函数的逃逸分析和内联结果：escape [--all] <课程> [函数 ...]", runEscape},
+	{"float", "拆解浮点数的符号、指数、尾数和精确值，展示运算的舍入误差：float [--32] [--full] <数或表达式>", runFloat},
+	{"types", "列出当前平台数值类型的大小、对齐和取值范围，以及能放下给定数值的类型：types [数值 ...]", runTypes},
+	{"arch", "分别在本机架构和 386 上编译运行课程，并排对比输出：arch [--goarch 386] [--all] <课程[/小节]> ...", runArch},
+}
+
+func main() {
+	os.Exit(dispatch(os.Args[1:]))
+}
+
+// dispatch 按第一个参数找到命令并运行，返回进程的退出码
+func dispatch(args []string) int {
+	if len(args) == 0 {
+		usage()
+		return 2
+	}
+	name, args := args[0], args[1:]
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+		if err := c.run(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return 2
+			}
+			fmt.Fprintln(os.Stderr, "golearn:", err)
+			return 1
+		}
+		return 0
+	}
+	if name != "help" && name != "-h" && name != "--help" {
+		fmt.Fprintf(os.Stderr, "golearn: 未知命令 %q\n", name)
+	}
+	usage()
+	return 2
+}
+
+// parseArgs 解析 args 中的参数，参数可以出现在位置参数之后（如 run 1_var --quiet），
+// 返回其余的位置参数。"--" 之后的内容都是位置参数。
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var flags, rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			rest = append(rest, args[i+1:]...)
+			i = len(args)
+		case isFlag(fs, arg):
+			flags = append(flags, arg)
+			// 非布尔参数写成 "--width 100" 时，下一个参数是它的值
+			if f := fs.Lookup(strings.TrimLeft(arg, "-")); f != nil && !isBool(f) && i+1 < len(args) {
+				i++
+				flags = append(flags, args[i])
+			}
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	if err := fs.Parse(flags); err != nil {
+		return nil, err
+	}
+	return rest, nil
+}
+
+// isFlag arg 是否为 fs 中定义的参数、"--" 或帮助参数
+func isFlag(fs *flag.FlagSet, arg string) bool {
+	if arg == "--" || arg == "-h" || arg == "-help" || arg == "--help" {
+		return true
+	}
+	name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+	return strings.HasPrefix(arg, "-") && fs.Lookup(name) != nil
+}
+
+func isBool(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "用法：golearn <命令> [参数]")
+	fmt.Fprintln(os.Stderr, "\n命令：")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", c.name, c.usage)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestParseArgs(t *testing.T) {
+	for _, tt := range []struct {
+		args  []string
+		quiet bool
+		width int
+		rest  []string
+	}{
+		{[]string{"1_var", "--quiet"}, true, 70, []string{"1_var"}},
+		{[]string{"-q", "1_var", "2_string"}, true, 70, []string{"1_var", "2_string"}},
+		{[]string{"1_var", "--width", "30", "2_string"}, false, 30, []string{"1_var", "2_string"}},
+		{[]string{"--width=40", "1_var"}, false, 40, []string{"1_var"}},
+		{[]string{"1_var", "--", "--quiet"}, false, 70, []string{"1_var", "--quiet"}},
+		{[]string{"-0.5", "--unknown"}, false, 70, []string{"-0.5", "--unknown"}},
+		{nil, false, 70, nil},
+	} {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		quiet := fs.Bool("quiet", false, "")
+		fs.BoolVar(quiet, "q", false, "")
+		width := fs.Int("width", 70, "")
+		rest, err := parseArgs(fs, tt.args)
+		if err != nil || *quiet != tt.quiet || *width != tt.width || !slices.Equal(rest, tt.rest) {
+			t.Errorf("parseArgs(%q) = %q, %v；quiet %t，width %d", tt.args, rest, err, *quiet, *width)
+		}
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	// 屏蔽用法说明和错误信息
+	stderr := os.Stderr
+	os.Stderr, _ = os.Open(os.DevNull)
+	defer func() { os.Stderr = stderr }()
+
+	for _, tt := range []struct {
+		args []string
+		code int
+	}{
+		{nil, 2},
+		{[]string{"help"}, 2},
+		{[]string{"nope"}, 2},
+		{[]string{"run", "--help"}, 2},
+		{[]string{"run", "1_var", "--quiet"}, 0},
+		{[]string{"run", "--quiet", "1_var/varSection1"}, 0},
+		{[]string{"run", "nope", "--quiet"}, 1},
+		{[]string{"float", "-0.5"}, 0},
+		{[]string{"types", "300"}, 0},
+	} {
+		var code int
+		out := captureStdout(t, func() { code = dispatch(tt.args) })
+		if code != tt.code {
+			t.Errorf("golearn %q 的退出码为 %d，期望 %d", tt.args, code, tt.code)
+		}
+		if slices.Contains(tt.args, "--quiet") && out != "" {
+			t.Errorf("golearn %q 静默运行时有输出：%q", tt.args, out)
+		}
+	}
+}
+
+// captureStdout 运行 f，返回它写到标准输出的内容
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		done <- string(b)
+	}()
+	f()
+	os.Stdout = stdout
+	w.Close()
+	return <-done
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/colayear/go_learning/lesson"
+	"github.com/colayear/go_learning/strutil"
+)
+
+// target 一个待运行的小节
+type target struct {
+	lesson  *lesson.Lesson
+	section *lesson.Section
+}
+
+func (t target) String() string { return t.lesson.ID + "/" + t.section.ID }
+
+func runRun(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	quiet := fs.Bool("quiet", false, "不输出小节内容，只报告失败")
+	fs.BoolVar(quiet, "q", false, "同 --quiet")
+	debugSlices := fs.Bool("debug-slices", false, "报告课程中按字节截取字符串时被截断的字符（见 strutil.Slice）")
+	paths, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	targets, err := resolve(paths)
+	if err != nil {
+		return err
+	}
+	if *debugSlices {
+		strutil.SetDebug(os.Stderr)
+		defer strutil.SetDebug(nil)
+	}
+
+	var out io.Writer // nil 表示直接输出到终端
+	if *quiet {
+		out = io.Discard
+	}
+	failed := 0
+	for _, t := range targets {
+		if !*quiet {
+			fmt.Printf("### %s（%s）\n", t, t.section.Title)
+		}
+		err := lesson.Exec(t.section, out)
+		if err == nil {
+			continue
+		}
+		failed++
+		fmt.Fprintf(os.Stderr, "✗ %s：%v\n", t, err)
+		var pe *lesson.PanicError
+		if errors.As(err, &pe) && !*quiet {
+			os.Stderr.Write(pe.Stack)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("共运行 %d 个小节，%d 个失败", len(targets), failed)
+	}
+	return nil
+}
+
+// resolve 把命令行参数展开为待运行的小节，不带参数时按顺序运行所有课程
+func resolve(paths []string) ([]target, error) {
+	var targets []target
+	if len(paths) == 0 {
+		for _, l := range lesson.All() {
+			targets = appendLesson(targets, l)
+		}
+		return targets, nil
+	}
+	for _, p := range paths {
+		l, s, err := lesson.Find(p)
+		if err != nil {
+			return nil, err
+		}
+		if s != nil {
+			targets = append(targets, target{l, s})
+		} else {
+			targets = appendLesson(targets, l)
+		}
+	}
+	return targets, nil
+}
+
+func appendLesson(targets []target, l *lesson.Lesson) []target {
+	for i := range l.Sections {
+		targets = append(targets, target{l, &l.Sections[i]})
+	}
+	return targets
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/colayear/go_learning/crossarch"
+	"github.com/colayear/go_learning/errexample"
+	"github.com/colayear/go_learning/lesson"
+)
+
+// runTry 把课程中注释掉的错误示例取消注释后真正编译一次，
+// 把注释里声称的报错和编译器的实际报错并排对照。
+func runTry(args []string) error {
+	fs := flag.NewFlagSet("try", flag.ContinueOnError)
+	width := fs.Int("width", 60, "每侧显示的列数")
+	args, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf("用法：golearn try [--width 60] <课程> [示例序号]")
+	}
+	if *width < 1 {
+		return fmt.Errorf("--width 需要是正整数，实际为 %d", *width)
+	}
+	l, ok := lesson.Get(args[0])
+	if !ok {
+		return fmt.Errorf("未知课程 %q", args[0])
+	}
+	if _, err := sourceDir(l); err != nil {
+		return err
+	}
+	src, err := os.ReadFile(l.File)
+	if err != nil {
+		return err
+	}
+	examples := errexample.Extract(l.File, src)
+	if len(examples) == 0 {
+		fmt.Printf("课程 %s 中没有注释掉的错误示例\n", l.ID)
+		return nil
+	}
+
+	if len(args) == 2 {
+		e, err := pick(examples, args[1])
+		if err != nil {
+			return err
+		}
+		return try(e, *width)
+	}
+
+	in := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Printf("课程 %s（%s）中的错误示例：\n", l.ID, l.Title)
+		for i, e := range examples {
+			fmt.Printf("  %d) 第 %d 行：%s\n", i+1, e.Line, e.Code)
+		}
+		fmt.Print("取消注释哪一条？（输入序号，直接回车退出）：")
+		if !in.Scan() || strings.TrimSpace(in.Text()) == "" {
+			fmt.Println()
+			return in.Err()
+		}
+		e, err := pick(examples, in.Text())
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		if err := try(e, *width); err != nil {
+			return err
+		}
+		fmt.Println()
+	}
+}
+
+func pick(examples []errexample.Example, s string) (errexample.Example, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 1 || n > len(examples) {
+		return errexample.Example{}, fmt.Errorf("示例序号应为 1 ~ %d", len(examples))
+	}
+	return examples[n-1], nil
+}
+
+func try(e errexample.Example, width int) error {
+	fmt.Printf("\n=== 取消注释 %s 第 %d 行 ===\n    %s\n", filepath.Base(e.File), e.Line, e.Code)
+	fmt.Println("编译中（go build）……")
+	r, err := errexample.Build(e)
+	if err != nil {
+		return err
+	}
+
+	if len(e.Note) > 0 {
+		fmt.Println("\n【注释中的说明】")
+		for _, n := range e.Note {
+			fmt.Println("  " + n)
+		}
+	}
+	fmt.Println()
+	res := errexample.Result{Example: e, Errors: r.Errors}
+	crossarch.WriteSideBySide(os.Stdout, "注释中的报错", "编译器实际报错", compare(res, r.Output), width, false)
+
+	if res.OK() {
+		fmt.Println("\n✓ 注释与编译器报错相符")
+	} else {
+		fmt.Println("\n✗ 注释与编译器报错不符，注释可能已过时")
+	}
+	return nil
+}
+
+// compare 把注释中的报错与编译器的报错逐行对齐：注释的那一行对着与它相符的报错，
+// 都不相符时对着第一条报错。该行没有报错时右侧显示编译器的输出。
+func compare(r errexample.Result, output string) []crossarch.Line {
+	got := r.Errors
+	switch {
+	case len(got) > 0:
+	case strings.TrimSpace(output) == "":
+		got = []string{"编译通过，没有报错"}
+	default:
+		got = append([]string{"该行没有报错，编译器输出："}, strings.Split(strings.TrimSpace(output), "\n")...)
+	}
+	at := max(r.Match(), 0)
+	lines := make([]crossarch.Line, len(got))
+	for i, g := range got {
+		lines[i] = crossarch.Line{Op: crossarch.Added, Right: g, RightNo: i + 1}
+	}
+	lines[at].Left, lines[at].LeftNo = r.Want, 1
+	if lines[at].Op = crossarch.Changed; r.Want == got[at] {
+		lines[at].Op = crossarch.Same
+	}
+	return lines
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/colayear/go_learning/crossarch"
+	"github.com/colayear/go_learning/errexample"
+)
+
+func TestCompare(t *testing.T) {
+	result := func(want string, errs ...string) errexample.Result {
+		return errexample.Result{Example: errexample.Example{Want: want}, Errors: errs}
+	}
+	for _, tt := range []struct {
+		name   string
+		r      errexample.Result
+		output string
+		want   []crossarch.Line
+	}{
+		{"对着相符的报错", result("overflows", "declared and not used: x", "constant 128 overflows int8"), "", []crossarch.Line{
+			{Op: crossarch.Added, Right: "declared and not used: x", RightNo: 1},
+			{Op: crossarch.Changed, Left: "overflows", Right: "constant 128 overflows int8", LeftNo: 1, RightNo: 2},
+		}},
+		{"完全相同", result("x", "x"), "", []crossarch.Line{
+			{Op: crossarch.Same, Left: "x", Right: "x", LeftNo: 1, RightNo: 1},
+		}},
+		{"都不相符", result("x", "a", "b"), "", []crossarch.Line{
+			{Op: crossarch.Changed, Left: "x", Right: "a", LeftNo: 1, RightNo: 1},
+			{Op: crossarch.Added, Right: "b", RightNo: 2},
+		}},
+		{"编译通过", result("x"), "", []crossarch.Line{
+			{Op: crossarch.Changed, Left: "x", Right: "编译通过，没有报错", LeftNo: 1, RightNo: 1},
+		}},
+		{"其他行报错", result("x"), "./a.go:3:1: y\n", []crossarch.Line{
+			{Op: crossarch.Changed, Left: "x", Right: "该行没有报错，编译器输出：", LeftNo: 1, RightNo: 1},
+			{Op: crossarch.Added, Right: "./a.go:3:1: y", RightNo: 2},
+		}},
+	} {
+		if got := compare(tt.r, tt.output); !slices.Equal(got, tt.want) {
+			t.Errorf("%s：compare = %+v，期望 %+v", tt.name, got, tt.want)
+		}
+	}
+}
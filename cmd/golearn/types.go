@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/colayear/go_learning/numtype"
+)
+
+// runTypes 打印当前平台所有内置数值类型的大小、对齐和范围；给出数值时再列出能放下它的类型
+func runTypes(args []string) error {
+	values := make([]any, len(args))
+	for i, arg := range args {
+		v, err := parseNumber(arg)
+		if err != nil {
+			return err
+		}
+		values[i] = v
+	}
+
+	fmt.Printf("GOOS=%s GOARCH=%s，指针占 %d 字节\n\n", runtime.GOOS, runtime.GOARCH, unsafe.Sizeof(uintptr(0)))
+	infos := make([]numtype.Info, len(numtype.Builtin))
+	for i, t := range numtype.Builtin {
+		info, err := numtype.Of(t)
+		if err != nil {
+			return err
+		}
+		infos[i] = info
+	}
+	numtype.WriteTable(os.Stdout, infos)
+
+	for i, v := range values {
+		var names []string
+		for _, info := range infos {
+			if info.Fits(v) {
+				names = append(names, info.Type.String())
+			}
+		}
+		if len(names) == 0 {
+			names = []string{"（没有）"}
+		}
+		fmt.Printf("\n%s 能原样放进：%s", args[i], strings.Join(names, " "))
+	}
+	if len(values) > 0 {
+		fmt.Println()
+	}
+	return nil
+}
+
+// parseNumber 依次按 int64、uint64、float64 解析，得到能精确表示 s 的值
+func parseNumber(s string) (any, error) {
+	if v, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return v, nil
+	}
+	if v, err := strconv.ParseUint(s, 0, 64); err == nil {
+		return v, nil
+	}
+	if v, err := strconv.ParseFloat(s, 64); err == nil {
+		return v, nil
+	}
+	return nil, fmt.Errorf("%q 不是数", s)
+}
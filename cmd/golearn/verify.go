@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/colayear/go_learning/errexample"
+	"github.com/colayear/go_learning/lesson"
+)
+
+// runVerify 校验课程中注释掉的错误示例确实会产生注释所说的编译错误
+func runVerify(args []string) error {
+	lessons, err := findLessons(args)
+	if err != nil {
+		return err
+	}
+
+	// 同一目录下的课程属于同一个包，每个目录只做一次类型检查
+	files := map[string]bool{}
+	var dirs []string
+	for _, l := range lessons {
+		dir, err := sourceDir(l)
+		if err != nil {
+			return err
+		}
+		files[l.File] = true
+		if !contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	total, failed := 0, 0
+	for _, dir := range dirs {
+		results, err := errexample.Verify(dir)
+		if err != nil {
+			return err
+		}
+		for _, r := range results {
+			if !files[r.File] {
+				continue
+			}
+			total++
+			if r.OK() {
+				fmt.Printf("✓ %s\n", r.Example)
+				continue
+			}
+			failed++
+			fmt.Printf("✗ %s\n    注释：%s\n", r.Example, r.Want)
+			if len(r.Errors) == 0 {
+				fmt.Println("    实际：该行没有报错")
+			}
+			for _, e := range r.Errors {
+				fmt.Printf("    实际：%s\n", e)
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("共 %d 条错误示例，%d 条与实际编译错误不符", total, failed)
+	}
+	fmt.Printf("共 %d 条错误示例，全部与实际编译错误相符\n", total)
+	return nil
+}
+
+// findLessons 按 id 查找课程，不带参数时返回全部课程
+func findLessons(ids []string) ([]*lesson.Lesson, error) {
+	if len(ids) == 0 {
+		return lesson.All(), nil
+	}
+	var lessons []*lesson.Lesson
+	for _, id := range ids {
+		l, ok := lesson.Get(id)
+		if !ok {
+			return nil, fmt.Errorf("未知课程 %q", id)
+		}
+		lessons = append(lessons, l)
+	}
+	return lessons, nil
+}
+
+// sourceDir 返回课程源文件所在的目录。
+// 课程注册时记录的是编译时的源码路径，用 go install 安装的程序换一台机器或删掉仓库后就找不到了。
+func sourceDir(l *lesson.Lesson) (string, error) {
+	if _, err := os.Stat(l.File); err != nil {
+		return "", fmt.Errorf("找不到课程 %s 的源码 %s：该命令需要读取课程源码，请在仓库目录中用 go run ./cmd/golearn 运行", l.ID, l.File)
+	}
+	return filepath.Dir(l.File), nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package cnnum
+
+import "strings"
+
+// 大写金额按《正确填写票据和结算凭证的基本规定》书写：
+//
+//   - 数字中间有 0 时写“零”，连续几个 0 只写一个“零”：¥6007.14 → 陆仟零柒元壹角肆分
+//   - 万位、元位是 0 而千位、角位不是 0 时，“零”可写可不写。这里采用规定中的第一种写法：
+//     万位后不写“零”，元位是 0 而角位不是 0 时“元”后写“零”：¥107000.53 → 壹拾万柒仟元零伍角叁分、¥1680.32 → 壹仟陆佰捌拾元零叁角贰分
+//   - 角位是 0 而分位不是 0 时，“元”后面必须写“零”：¥16409.02 → 壹万陆仟肆佰零玖元零贰分
+//   - 到“元”为止的，“元”后写“整”（或“正”）；到“角”为止的可写可不写；有“分”的不写
+//   - 开头的“壹拾”不能省略写作“拾”：¥10.00 → 壹拾元整
+
+// AmountOptions 大写金额的写法
+type AmountOptions struct {
+	Prefix             string // 前缀，如 "人民币"
+	Whole              string // 到元或角为止时的后缀，为空时使用 "整"，也可以用 "正"
+	OmitWholeAfterJiao bool   // 到角为止时不写后缀，如 "壹元伍角"
+}
+
+// Amount 把以分为单位的金额写成大写，如 1001 → "壹拾元零壹分"、1000 → "壹拾元整"
+func Amount(cents int64) string { return AmountOptions{}.Format(cents) }
+
+// Format 按 o 的写法把以分为单位的金额写成大写。负数在金额前加“负”，如 "人民币负壹拾元整"。
+func (o AmountOptions) Format(cents int64) string {
+	var b strings.Builder
+	b.WriteString(o.Prefix)
+	u := uint64(cents)
+	if cents < 0 {
+		b.WriteString("负")
+		u = -u
+	}
+	yuan, jiao, fen := u/100, u/10%10, u%10
+	whole := o.Whole
+	if whole == "" {
+		whole = "整"
+	}
+
+	switch {
+	case yuan > 0:
+		upper.formatUint(&b, yuan, true)
+		b.WriteString("元")
+	case jiao == 0 && fen == 0:
+		b.WriteString("零元")
+	}
+	// 角位是 0、分位不是 0，或元位是 0、角位不是 0：元后写“零”
+	if yuan > 0 && (jiao == 0 && fen > 0 || yuan%10 == 0 && jiao > 0) {
+		b.WriteString(upper.digits[0])
+	}
+	if jiao > 0 {
+		b.WriteString(upper.digits[jiao] + "角")
+	}
+	switch {
+	case fen > 0:
+		b.WriteString(upper.digits[fen] + "分")
+	case jiao == 0 || !o.OmitWholeAfterJiao:
+		b.WriteString(whole)
+	}
+	return b.String()
+}
@@ -0,0 +1,166 @@
+package cnnum
+
+import (
+	"math"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestAmount(t *testing.T) {
+	for _, tt := range []struct {
+		cents int64
+		want  string
+	}{
+		// 课程中的例子
+		{1001, "壹拾元零壹分"},
+		{1000, "壹拾元整"},
+		// 零的位置
+		{0, "零元整"},
+		{5, "伍分"},
+		{50, "伍角整"},
+		{55, "伍角伍分"},
+		{100, "壹元整"},
+		{101, "壹元零壹分"},
+		{110, "壹元壹角整"},
+		{1500, "壹拾伍元整"},
+		{3070, "叁拾元零柒角整"},
+		{10000, "壹佰元整"},
+		{10500, "壹佰零伍元整"},
+		{11000, "壹佰壹拾元整"},
+		{100010, "壹仟元零壹角整"},
+		{101000, "壹仟零壹拾元整"},
+		{110000, "壹仟壹佰元整"},
+		{100000001, "壹佰万元零壹分"},
+		{1000000, "壹万元整"},
+		{1000500, "壹万零伍元整"},
+		{1005000, "壹万零伍拾元整"},
+		{1050000, "壹万零伍佰元整"},
+		{1500000, "壹万伍仟元整"},
+		{10001000, "壹拾万零壹拾元整"},
+		{10050000, "壹拾万零伍佰元整"},
+		{123456789, "壹佰贰拾叁万肆仟伍佰陆拾柒元捌角玖分"},
+		{10000000000, "壹亿元整"},
+		{10000000100, "壹亿零壹元整"},
+		{12000000000, "壹亿贰仟万元整"},
+		{10005000000, "壹亿零伍万元整"},
+		{10500000000, "壹亿零伍佰万元整"},
+		{100000000000000, "壹万亿元整"},
+		{200030000000000, "贰万零叁亿元整"},
+		// 负数
+		{-1000, "负壹拾元整"},
+		{-1, "负壹分"},
+		{math.MaxInt64, "玖亿贰仟贰佰叁拾叁万柒仟贰佰零叁亿陆仟捌佰伍拾肆万柒仟柒佰伍拾捌元零柒分"},
+		{math.MinInt64, "负玖亿贰仟贰佰叁拾叁万柒仟贰佰零叁亿陆仟捌佰伍拾肆万柒仟柒佰伍拾捌元零捌分"},
+	} {
+		if got := Amount(tt.cents); got != tt.want {
+			t.Errorf("Amount(%d) = %q，期望 %q", tt.cents, got, tt.want)
+		}
+	}
+}
+
+// 《正确填写票据和结算凭证的基本规定》中的例子，两种写法都允许时取第一种
+func TestAmountOfficial(t *testing.T) {
+	opts := AmountOptions{Prefix: "人民币", OmitWholeAfterJiao: true}
+	for _, tt := range []struct {
+		cents int64
+		want  string
+	}{
+		{600714, "人民币陆仟零柒元壹角肆分"},
+		{168032, "人民币壹仟陆佰捌拾元零叁角贰分"},
+		{10700053, "人民币壹拾万柒仟元零伍角叁分"},
+		{1640902, "人民币壹万陆仟肆佰零玖元零贰分"},
+		{32504, "人民币叁佰贰拾伍元零肆分"},
+		{140950, "人民币壹仟肆佰零玖元伍角"},
+	} {
+		if got := opts.Format(tt.cents); got != tt.want {
+			t.Errorf("Format(%d) = %q，期望 %q", tt.cents, got, tt.want)
+		}
+	}
+}
+
+func TestAmountOptions(t *testing.T) {
+	for _, tt := range []struct {
+		opts  AmountOptions
+		cents int64
+		want  string
+	}{
+		{AmountOptions{Prefix: "人民币"}, 1000, "人民币壹拾元整"},
+		{AmountOptions{Prefix: "人民币"}, -1000, "人民币负壹拾元整"},
+		{AmountOptions{Prefix: "人民币"}, 1001, "人民币壹拾元零壹分"},
+		{AmountOptions{Whole: "正"}, 1000, "壹拾元正"},
+		{AmountOptions{Whole: "正"}, 1010, "壹拾元零壹角正"},
+		{AmountOptions{Whole: "正"}, 1011, "壹拾元零壹角壹分"},
+		{AmountOptions{OmitWholeAfterJiao: true}, 140950, "壹仟肆佰零玖元伍角"},
+		{AmountOptions{OmitWholeAfterJiao: true}, 140900, "壹仟肆佰零玖元整"},
+		{AmountOptions{Prefix: "人民币", Whole: "正", OmitWholeAfterJiao: true}, 0, "人民币零元正"},
+	} {
+		if got := tt.opts.Format(tt.cents); got != tt.want {
+			t.Errorf("%+v.Format(%d) = %q，期望 %q", tt.opts, tt.cents, got, tt.want)
+		}
+	}
+}
+
+func TestFormatUpper(t *testing.T) {
+	for _, tt := range []struct {
+		n    int64
+		want string
+	}{
+		{0, "零"}, {10, "壹拾"}, {12, "壹拾贰"}, {20005, "贰万零伍"}, {-1010, "负壹仟零壹拾"},
+	} {
+		if got := FormatUpper(tt.n); got != tt.want {
+			t.Errorf("FormatUpper(%d) = %q，期望 %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+// toLower 把大写数字和单位换成小写，以便用 Parse 核对元的部分
+var toLower = strings.NewReplacer(
+	"壹", "一", "贰", "二", "叁", "三", "肆", "四", "伍", "五",
+	"陆", "六", "柒", "七", "捌", "八", "玖", "九", "拾", "十", "佰", "百", "仟", "千")
+
+// TestAmountConsistent 对大量金额核对：元的部分能被 Parse 解析回原值，角、分正确，“零”和“整”的位置符合规定
+func TestAmountConsistent(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	check := func(cents int64) {
+		s := Amount(cents)
+		u := uint64(cents)
+		if cents < 0 {
+			u = -u
+			if !strings.HasPrefix(s, "负") {
+				t.Fatalf("Amount(%d) = %q 缺少“负”", cents, s)
+			}
+		}
+		yuan, jiao, fen := u/100, u/10%10, u%10
+		if strings.Contains(s, "零零") || strings.Contains(s, "零角") || strings.Contains(s, "零分") ||
+			strings.HasSuffix(s, "零") || strings.Contains(s, "零元") != (u == 0) {
+			t.Fatalf("Amount(%d) = %q 中“零”的位置不对", cents, s)
+		}
+		if strings.HasSuffix(s, "整") == (fen > 0) {
+			t.Fatalf("Amount(%d) = %q 中“整”的位置不对", cents, s)
+		}
+		if fen > 0 && jiao == 0 && yuan > 0 && !strings.Contains(s, "元零") {
+			t.Fatalf("Amount(%d) = %q 角位为 0 时元后应写“零”", cents, s)
+		}
+		if yuan > 0 {
+			head, _, _ := strings.Cut(strings.TrimPrefix(s, "负"), "元")
+			if strings.HasPrefix(head, "拾") {
+				t.Fatalf("Amount(%d) = %q 开头的“壹拾”不能省略", cents, s)
+			}
+			got, err := Parse(toLower.Replace(head))
+			if err != nil || uint64(got) != yuan {
+				t.Fatalf("Amount(%d) = %q，元的部分解析为 %d, %v", cents, s, got, err)
+			}
+		}
+		if jiao > 0 && !strings.Contains(s, upper.digits[jiao]+"角") || fen > 0 && !strings.HasSuffix(s, upper.digits[fen]+"分") {
+			t.Fatalf("Amount(%d) = %q 的角、分不对", cents, s)
+		}
+	}
+	for c := int64(-1000); c <= 100000; c++ {
+		check(c)
+	}
+	for i := 0; i < 20000; i++ {
+		check(int64(r.Uint64() >> 1))
+		check(r.Int64N(1 << (r.IntN(62) + 1)))
+	}
+}
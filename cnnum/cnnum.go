@@ -0,0 +1,504 @@
+// Package cnnum 解析和格式化中文小写数字。
+//
+//	Format(123)        "一百二十三"
+//	Format(20005)      "二万零五"
+//	Parse("两万零五")    20005
+//	Parse("1.2亿")      120000000
+//	Abbrev(12300, 2)   "1.23万"
+//	Amount(1001)       "壹拾元零壹分"（大写金额，见 amount.go）
+//
+// 亿以上不再引入新单位，而是把亿之前的部分当作一个数来读：10¹² 读作“一万亿”，
+// 10¹⁶ 读作“一亿亿”，2000300000000 读作“二万零三亿”。
+// int64 的所有值都能用 Format 表示，并由 Parse 原样解析回来。
+package cnnum
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// 解析失败的原因，可以用 errors.Is 判断
+var (
+	ErrSyntax = errors.New("cnnum: 格式错误")
+	ErrRange  = errors.New("cnnum: 超出 int64 的范围")
+)
+
+// Error 解析失败的详细信息
+type Error struct {
+	Input  string
+	Offset int    // 出错位置在 Input 中的字节下标
+	Reason string // 具体原因
+	Err    error  // ErrSyntax 或 ErrRange
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("cnnum: 解析 %q 失败：第 %d 字节处%s", e.Input, e.Offset, e.Reason)
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+var digits = [...]string{"零", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+
+// numerals 一套数字写法：小写用于日常读数，大写用于金额，防止涂改
+type numerals struct {
+	digits       [10]string
+	units        [4]string // 千、百、十、个位
+	wan, yi      string
+	omitShiDigit bool // 10 ~ 19 开头时省略“一”，如 "十二"
+}
+
+var (
+	lower = numerals{digits, [4]string{"千", "百", "十", ""}, "万", "亿", true}
+	upper = numerals{
+		[10]string{"零", "壹", "贰", "叁", "肆", "伍", "陆", "柒", "捌", "玖"},
+		[4]string{"仟", "佰", "拾", ""}, "万", "亿", false,
+	}
+)
+
+// Format n 的中文小写读法。10 ~ 19 开头时省略“一”，如 "十二"、"十万"；节内与节间的 0 读作一个“零”。
+func Format(n int64) string { return lower.format(n) }
+
+// FormatUpper n 的中文大写写法，如 "壹万贰仟零伍"。与 Format 不同，开头的“壹拾”不省略。
+func FormatUpper(n int64) string { return upper.format(n) }
+
+func (nu *numerals) format(n int64) string {
+	if n == 0 {
+		return nu.digits[0]
+	}
+	var b strings.Builder
+	u := uint64(n)
+	if n < 0 {
+		b.WriteString("负")
+		u = -u
+	}
+	nu.formatUint(&b, u, true)
+	return b.String()
+}
+
+// formatUint 写出 u（大于 0），亿以上的部分递归地读作“某某亿”
+func (nu *numerals) formatUint(b *strings.Builder, u uint64, first bool) {
+	if u < 1e8 {
+		nu.formatWan(b, u, first)
+		return
+	}
+	nu.formatUint(b, u/1e8, first)
+	b.WriteString(nu.yi)
+	if low := u % 1e8; low > 0 {
+		if low < 1e7 {
+			b.WriteString(nu.digits[0])
+		}
+		nu.formatWan(b, low, false)
+	}
+}
+
+// formatWan 写出 1 ~ 99999999，即“某某万某某”
+func (nu *numerals) formatWan(b *strings.Builder, u uint64, first bool) {
+	hi, lo := u/1e4, u%1e4
+	if hi > 0 {
+		nu.formatSection(b, hi, first)
+		b.WriteString(nu.wan)
+	}
+	if lo > 0 {
+		if hi > 0 && lo < 1000 {
+			b.WriteString(nu.digits[0])
+		}
+		nu.formatSection(b, lo, first && hi == 0)
+	}
+}
+
+// formatSection 写出一节（1 ~ 9999），first 表示是整个数的第一节。
+// 节内数字之间的 0 读作一个“零”，末尾的 0 不读，开头的 0 由 formatWan、formatUint 处理。
+func (nu *numerals) formatSection(b *strings.Builder, sec uint64, first bool) {
+	seen, zero := false, false
+	for i, p := 0, uint64(1000); p > 0; i, p = i+1, p/10 {
+		d := sec / p % 10
+		if d == 0 {
+			zero = seen
+			continue
+		}
+		if zero {
+			b.WriteString(nu.digits[0])
+			zero = false
+		}
+		if !(nu.omitShiDigit && first && !seen && d == 1 && p == 10) {
+			b.WriteString(nu.digits[d])
+		}
+		b.WriteString(nu.units[i])
+		seen = true
+	}
+}
+
+// Abbrev 用万、亿、万亿缩写 n，保留至多 prec 位小数（四舍五入，去掉末尾的 0），
+// 如 Abbrev(12300, 2) 为 "1.23万"。绝对值小于 10000 时直接返回阿拉伯数字。
+func Abbrev(n int64, prec int) string {
+	u := uint64(n)
+	sign := ""
+	if n < 0 {
+		sign, u = "-", -u
+	}
+	if u < 10000 {
+		return sign + strconv.FormatUint(u, 10)
+	}
+	prec = max(prec, 0)
+	units := [...]struct {
+		exp  int
+		name string
+	}{{12, "万亿"}, {8, "亿"}, {4, "万"}}
+	i := 0
+	for u < pow10(units[i].exp) {
+		i++
+	}
+	for ; ; i-- {
+		unit := units[i]
+		p := min(prec, unit.exp)
+		scale := pow10(unit.exp - p)
+		q := u / scale
+		if u%scale*2 >= scale { // 四舍五入
+			q++
+		}
+		whole, frac := q/pow10(p), q%pow10(p)
+		// 进位后可能达到上一级单位，如 99999999 → 10000万，改用亿
+		if whole >= 10000 && i > 0 {
+			continue
+		}
+		s := sign + strconv.FormatUint(whole, 10)
+		if f := strings.TrimRight(fmt.Sprintf("%0*d", p, frac), "0"); f != "" {
+			s += "." + f
+		}
+		return s + unit.name
+	}
+}
+
+func pow10(e int) uint64 {
+	p := uint64(1)
+	for ; e > 0; e-- {
+		p *= 10
+	}
+	return p
+}
+
+// Parse 解析中文小写数字，也接受阿拉伯数字与单位混写：
+//
+//	"一百二十三"  "两万零五"  "十五"  "负三"     标准读法，“两”等同于“二”
+//	"三千五"  "一万五"                        口语中省略末尾的单位，即 3500、15000
+//	"二〇二四"  "一二三"                       只有数字时逐位读，即 2024、123
+//	"1.2亿"  "3万5千"  "12万"                  阿拉伯数字加单位，小数必须后跟单位且结果为整数
+func Parse(s string) (int64, error) {
+	p := parser{input: s}
+	u, neg, err := p.parse()
+	if err != nil {
+		return 0, err
+	}
+	if neg {
+		if u > 1<<63 {
+			return 0, p.rangeError()
+		}
+		return -int64(u), nil
+	}
+	if u > 1<<63-1 {
+		return 0, p.rangeError()
+	}
+	return int64(u), nil
+}
+
+type group struct {
+	value uint64
+	exp   int // 这一组的单位指数，如 "二万亿" 为 12
+}
+
+type parser struct {
+	input string
+	stack []group // 已遇到万、亿的各组，指数从大到小
+
+	section  uint64 // 当前节中已确定的值（遇到十、百、千后累加）
+	num      uint64 // 当前还没遇到单位的数字
+	hasNum   bool
+	frac     string // num 是阿拉伯小数时的小数部分
+	fracPos  int    // 小数的位置，用于报错
+	numChar  bool   // num 是单个中文数字（用于口语省略单位）
+	smallExp int    // 当前节中上一个十、百、千的指数，节开始时为 4
+	lastExp  int    // 上一个单位的指数，用于口语省略单位
+	zero     bool   // 上一个单位之后出现过“零”
+
+	zeroSinceBig   bool // 上一个万、亿之后出现过“零”
+	arabicSinceBig bool // 上一个万、亿之后出现过阿拉伯数字
+}
+
+func (p *parser) syntaxError(off int, format string, args ...any) *Error {
+	return &Error{Input: p.input, Offset: off, Reason: fmt.Sprintf(format, args...), Err: ErrSyntax}
+}
+
+func (p *parser) rangeError() *Error {
+	return &Error{Input: p.input, Offset: 0, Reason: "的数值超出 int64 的范围", Err: ErrRange}
+}
+
+func (p *parser) parse() (u uint64, neg bool, err error) {
+	s := strings.TrimSpace(p.input)
+	start := strings.Index(p.input, s)
+	if s == "" {
+		return 0, false, p.syntaxError(len(p.input), "没有数字")
+	}
+	if r, n := utf8.DecodeRuneInString(s); r == '负' || r == '-' {
+		neg = true
+		s = s[n:]
+		start += n
+	}
+	if s == "" {
+		return 0, false, p.syntaxError(len(p.input), "缺少数字")
+	}
+	if v, ok := digitOnly(s); ok {
+		return v, neg, nil
+	}
+
+	p.smallExp = 4
+	for i := 0; i < len(s); {
+		off := start + i
+		r, n := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case '0' <= r && r <= '9' || r == '.':
+			j := i
+			for j < len(s) && ('0' <= s[j] && s[j] <= '9' || s[j] == '.') {
+				j++
+			}
+			if err := p.arabic(s[i:j], off); err != nil {
+				return 0, false, err
+			}
+			i = j
+			continue
+		case digitValue(r) >= 0:
+			d := digitValue(r)
+			if d == 0 {
+				if p.hasNum {
+					return 0, false, p.syntaxError(off, "的“零”前面缺少单位")
+				}
+				p.zero, p.zeroSinceBig = true, true
+				break
+			}
+			if p.hasNum {
+				return 0, false, p.syntaxError(off, "的 %q 与前一个数字之间缺少单位", r)
+			}
+			p.num, p.hasNum, p.numChar = uint64(d), true, true
+		case smallUnit(r) > 0:
+			if err := p.small(r, smallUnit(r), off); err != nil {
+				return 0, false, err
+			}
+		case r == '万' || r == '亿':
+			e := 4
+			if r == '亿' {
+				e = 8
+			}
+			if err := p.big(e, off); err != nil {
+				return 0, false, err
+			}
+		default:
+			return 0, false, p.syntaxError(off, "的 %q 不是数字或单位", r)
+		}
+		i += n
+	}
+	if p.frac != "" {
+		return 0, false, p.syntaxError(p.fracPos, "的小数后面缺少单位")
+	}
+	total, ok := p.section, true
+	if p.hasNum {
+		v := p.num
+		if p.numChar && !p.zero && p.lastExp > 0 { // 口语省略单位："三千五" 即 3500
+			v *= pow10(p.lastExp - 1)
+		}
+		total, ok = addChecked(total, v)
+	}
+	for _, g := range p.stack {
+		if !ok {
+			break
+		}
+		total, ok = addChecked(total, g.value)
+	}
+	if !ok {
+		return 0, false, p.rangeError()
+	}
+	return total, neg, nil
+}
+
+// arabic 处理一段阿拉伯数字（可以带小数）
+func (p *parser) arabic(tok string, off int) error {
+	if p.hasNum {
+		return p.syntaxError(off, "的 %q 与前一个数字之间缺少单位", tok)
+	}
+	intPart, frac, _ := strings.Cut(tok, ".")
+	if strings.Contains(frac, ".") || intPart == "" && frac == "" {
+		return p.syntaxError(off, "的 %q 不是合法的数字", tok)
+	}
+	v := uint64(0)
+	if intPart != "" {
+		var err error
+		if v, err = strconv.ParseUint(intPart, 10, 64); err != nil {
+			return p.rangeError()
+		}
+	}
+	p.num, p.hasNum, p.numChar = v, true, false
+	p.arabicSinceBig = true
+	p.frac = strings.TrimRight(frac, "0")
+	p.fracPos = off
+	return nil
+}
+
+// takeNum 取出当前数字乘以 10^exp 的值（包括小数部分），没有数字时返回 0
+func (p *parser) takeNum(exp int) (uint64, error) {
+	if !p.hasNum {
+		return 0, nil
+	}
+	v, ok := mulChecked(p.num, pow10(exp))
+	if p.frac != "" {
+		if len(p.frac) > exp {
+			return 0, p.syntaxError(p.fracPos, "的小数位数太多，乘以单位后不是整数")
+		}
+		f, _ := strconv.ParseUint(p.frac, 10, 64)
+		var ok2 bool
+		v, ok2 = addChecked(v, f*pow10(exp-len(p.frac)))
+		ok = ok && ok2
+	}
+	if !ok {
+		return 0, p.rangeError()
+	}
+	p.num, p.hasNum, p.frac = 0, false, ""
+	return v, nil
+}
+
+// small 处理十、百、千
+func (p *parser) small(r rune, e int, off int) error {
+	if !p.hasNum {
+		// "十二"、"一百十二" 中的十可以省略前面的“一”
+		if r != '十' || p.zero {
+			return p.syntaxError(off, "的“%c”前面缺少数字", r)
+		}
+		p.num, p.hasNum = 1, true
+	}
+	if e >= p.smallExp {
+		return p.syntaxError(off, "的“%c”不能出现在更小的单位之后", r)
+	}
+	v, err := p.takeNum(e)
+	if err != nil {
+		return err
+	}
+	p.section += v
+	p.smallExp, p.lastExp, p.zero = e, e, false
+	return nil
+}
+
+// big 处理万、亿。单位 10^e 乘以它前面不足 10^2e 的部分：
+// 万乘以前面的万级各组（"一万万"），亿乘以前面的亿级和万级各组（"二万零三亿"、"九百二十二亿三千三百七十二万零三百六十八亿"）。
+// 单位紧跟单位时（"二万亿"、"一亿亿"）至少乘以前一组。
+func (p *parser) big(e int, off int) error {
+	unit := "万"
+	if e == 8 {
+		unit = "亿"
+	}
+	empty := !p.hasNum && p.section == 0
+	if empty && (len(p.stack) == 0 || p.zero) {
+		return p.syntaxError(off, "的“%s”前面缺少数字", unit)
+	}
+	if !empty && len(p.stack) > 0 && !p.zeroSinceBig && !p.arabicSinceBig {
+		// "一亿二万" 应写作 "一亿零二万"，否则可能被理解为口语的 "一亿二千万"
+		if v := p.section/pow10(p.smallExp) + p.num; p.section < 1000 && v < 1000 {
+			return p.syntaxError(off, "的“%s”之前的数字不足千位，前面缺少“零”", unit)
+		}
+	}
+	v, err := p.takeNum(e)
+	if err != nil {
+		return err
+	}
+	sum, ok := mulChecked(p.section, pow10(e))
+	sum, ok2 := addChecked(sum, v)
+	ok = ok && ok2
+	exp := e
+	for n := len(p.stack); n > 0; n-- {
+		top := p.stack[n-1]
+		if top.exp >= 2*e && !empty {
+			break
+		}
+		g, ok3 := mulChecked(top.value, pow10(e))
+		sum, ok2 = addChecked(sum, g)
+		ok = ok && ok2 && ok3
+		exp = max(exp, top.exp+e)
+		p.stack = p.stack[:n-1]
+		empty = false
+	}
+	if !ok {
+		return p.rangeError()
+	}
+	if n := len(p.stack); n > 0 && p.stack[n-1].exp <= exp {
+		return p.syntaxError(off, "的“%s”与前面的单位重复", unit)
+	}
+	p.stack = append(p.stack, group{sum, exp})
+	p.section, p.smallExp, p.lastExp = 0, 4, e
+	p.zero, p.zeroSinceBig, p.arabicSinceBig = false, false, false
+	return nil
+}
+
+// digitOnly 只由中文数字组成时逐位解析，如 "二〇二四"。
+// 超出 uint64 时返回 math.MaxUint64，由 Parse 报告超出范围，而不是当作缺少单位。
+func digitOnly(s string) (uint64, bool) {
+	if s == "" {
+		return 0, false
+	}
+	var v uint64
+	overflow := false
+	for _, r := range s {
+		d := digitValue(r)
+		if d < 0 {
+			return 0, false
+		}
+		if overflow {
+			continue
+		}
+		var ok1, ok2 bool
+		v, ok1 = mulChecked(v, 10)
+		v, ok2 = addChecked(v, uint64(d))
+		overflow = !ok1 || !ok2
+	}
+	if overflow {
+		return math.MaxUint64, true
+	}
+	return v, true
+}
+
+func digitValue(r rune) int {
+	switch r {
+	case '零', '〇':
+		return 0
+	case '两':
+		return 2
+	}
+	for i, d := range digits {
+		if d == string(r) {
+			return i
+		}
+	}
+	return -1
+}
+
+func smallUnit(r rune) int {
+	switch r {
+	case '十':
+		return 1
+	case '百':
+		return 2
+	case '千':
+		return 3
+	}
+	return 0
+}
+
+func mulChecked(a, b uint64) (uint64, bool) {
+	hi, lo := bits.Mul64(a, b)
+	return lo, hi == 0
+}
+
+func addChecked(a, b uint64) (uint64, bool) {
+	s, carry := bits.Add64(a, b, 0)
+	return s, carry == 0
+}
@@ -0,0 +1,196 @@
+package cnnum
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	for _, tt := range []struct {
+		n    int64
+		want string
+	}{
+		{0, "零"},
+		{7, "七"},
+		{10, "十"},
+		{12, "十二"},
+		{20, "二十"},
+		{105, "一百零五"},
+		{110, "一百一十"},
+		{123, "一百二十三"},
+		{1001, "一千零一"},
+		{1010, "一千零一十"},
+		{10000, "一万"},
+		{20005, "二万零五"},
+		{100000, "十万"},
+		{150000, "十五万"},
+		{1000010, "一百万零一十"},
+		{10010000, "一千零一万"},
+		{100000000, "一亿"},
+		{120000000, "一亿二千万"},
+		{100001000, "一亿零一千"},
+		{1000000000000, "一万亿"},
+		{2000300000000, "二万零三亿"},
+		{10000000000000000, "一亿亿"},
+		{20003000000000000, "二亿零三万亿"},
+		{10000000500000000, "一亿零五亿"},
+		{-305, "负三百零五"},
+		{math.MaxInt64, "九百二十二亿三千三百七十二万零三百六十八亿五千四百七十七万五千八百零七"},
+		{math.MinInt64, "负九百二十二亿三千三百七十二万零三百六十八亿五千四百七十七万五千八百零八"},
+	} {
+		if got := Format(tt.n); got != tt.want {
+			t.Errorf("Format(%d) = %q，期望 %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want int64
+	}{
+		{"一百二十三", 123},
+		{"两万零五", 20005},
+		{"二万零五", 20005},
+		{"十五", 15},
+		{"一百十二", 112},
+		{"三千五", 3500},
+		{"一万五", 15000},
+		{"两百五", 250},
+		{"负三", -3},
+		{" 十 ", 10},
+		{"二〇二四", 2024},
+		{"一二三", 123},
+		{"零", 0},
+		{"1.2亿", 120000000},
+		{"1.23万", 12300},
+		{"1.5千", 1500},
+		{"3万5千", 35000},
+		{"12万", 120000},
+		{"1.2万亿", 1200000000000},
+		{"123", 123},
+		{"-1.5万", -15000},
+		{"一亿亿", 1e16},
+		{"五千万亿", 5e15},
+		{"二万三千亿", 2.3e12},
+		{"二亿亿零三万亿", 20003000000000000},
+		{"1亿50万", 100500000},
+	} {
+		got, err := Parse(tt.s)
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %d, %v，期望 %d", tt.s, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tt := range []struct {
+		s      string
+		want   error
+		offset int
+	}{
+		{"", ErrSyntax, 0},
+		{"负", ErrSyntax, 3},
+		{"一二百", ErrSyntax, 3},
+		{"一百二千", ErrSyntax, 9},
+		{"百", ErrSyntax, 0},
+		{"万", ErrSyntax, 0},
+		{"三亿二亿", ErrSyntax, 9},
+		{"一百零十", ErrSyntax, 9},
+		{"一亿二万", ErrSyntax, 9},
+		{"二万亿零亿", ErrSyntax, 12},
+		{"三个", ErrSyntax, 3},
+		{"1.5", ErrSyntax, 0},
+		{"1.23456万", ErrSyntax, 0},
+		{"1.2.3万", ErrSyntax, 0},
+		{"3五", ErrSyntax, 1},
+		{"一亿亿亿", ErrRange, 0},
+		{"九二二三三七二〇三六八五四七七五八〇八", ErrRange, 0},
+		{"一二三四五六七八九〇一二三四五六七八九〇一二三", ErrRange, 0},
+		{"负九二二三三七二〇三六八五四七七五八〇九", ErrRange, 0},
+		{"九百二十三亿亿", ErrRange, 0},
+		{"九百二十二亿三千三百七十二万零三百六十八亿五千四百七十七万五千八百零八", ErrRange, 0},
+	} {
+		_, err := Parse(tt.s)
+		var e *Error
+		if !errors.As(err, &e) || !errors.Is(err, tt.want) || e.Offset != tt.offset {
+			t.Errorf("Parse(%q) 的错误为 %v，期望 %v、位置 %d", tt.s, err, tt.want, tt.offset)
+		}
+	}
+}
+
+func TestAbbrev(t *testing.T) {
+	for _, tt := range []struct {
+		n    int64
+		prec int
+		want string
+	}{
+		{9999, 2, "9999"},
+		{12300, 2, "1.23万"},
+		{12345, 2, "1.23万"},
+		{12350, 2, "1.24万"},
+		{10000, 2, "1万"},
+		{15000, 0, "2万"},
+		{99999999, 2, "1亿"},
+		{123456789, 1, "1.2亿"},
+		{-12300, 1, "-1.2万"},
+		{1234567890123, 3, "1.235万亿"},
+		{math.MaxInt64, 2, "9223372.04万亿"}, // 最大的单位是万亿
+	} {
+		if got := Abbrev(tt.n, tt.prec); got != tt.want {
+			t.Errorf("Abbrev(%d, %d) = %q，期望 %q", tt.n, tt.prec, got, tt.want)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for n := int64(-20000); n <= 200000; n++ {
+		roundTrip(t, n)
+	}
+	for e := 0; e < 19; e++ {
+		p := int64(math.Pow10(e))
+		for _, n := range []int64{p - 1, p, p + 1, 2*p + 1} {
+			roundTrip(t, n)
+			roundTrip(t, -n)
+		}
+	}
+	r := rand.New(rand.NewPCG(1, 2))
+	for i := 0; i < 20000; i++ {
+		roundTrip(t, int64(r.Uint64()))
+		roundTrip(t, r.Int64N(1<<(r.IntN(62)+1)))
+	}
+	roundTrip(t, math.MaxInt64)
+	roundTrip(t, math.MinInt64)
+}
+
+func roundTrip(t *testing.T, n int64) {
+	t.Helper()
+	s := Format(n)
+	if got, err := Parse(s); err != nil || got != n {
+		t.Fatalf("Parse(Format(%d) = %q) = %d, %v", n, s, got, err)
+	}
+	if got, err := Parse(strconv.FormatInt(n, 10)); err != nil || got != n {
+		t.Fatalf("Parse(%q) = %d, %v", strconv.FormatInt(n, 10), got, err)
+	}
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add(int64(20005))
+	f.Add(int64(math.MinInt64))
+	f.Fuzz(func(t *testing.T, n int64) { roundTrip(t, n) })
+}
+
+func FuzzParse(f *testing.F) {
+	for _, s := range []string{"一百二十三", "1.2亿", "二亿零三万亿", "三千五"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		n, err := Parse(s) // 任意输入都不能 panic；解析成功时结果应当能原样格式化、再解析回来
+		if err == nil {
+			roundTrip(t, n)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+// Package concat 对比各种字符串拼接方式的性能。
+//
+// 2_string.go 中说循环里用 += 拼接性能差、strings.Builder 快 10 倍以上，
+// 本包把常见的拼接方式放在一起，用基准测试在不同的片段个数和片段大小（包括多字节中文）下实测：
+//
+//	go test ./concat -run '^$' -bench . -benchmem
+//
+// go generate ./concat 运行基准测试并生成 basic/concat_bench.txt，由 2_string 课程直接打印。
+package concat
+
+//go:generate go run gen.go -o ../basic/concat_bench.txt
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/colayear/go_learning/strpool"
+)
+
+// Strategy 一种拼接方式：把所有片段按顺序拼接成一个字符串
+type Strategy struct {
+	Name   string // 基准测试中的名字，如 "builder_grow"
+	Desc   string // 说明，如 "strings.Builder + Grow"
+	Concat func(pieces []string) string
+}
+
+// Strategies 参与对比的拼接方式
+var Strategies = []Strategy{
+	{"plus", "+= 循环拼接", plus},
+	{"sprintf", "fmt.Sprintf 循环拼接", sprintf},
+	{"join", "strings.Join", join},
+	{"buffer", "bytes.Buffer", buffer},
+	{"builder", "strings.Builder", builder},
+	{"builder_grow", "strings.Builder + Grow", builderGrow},
+	{"pool", "sync.Pool 复用 bytes.Buffer", pooled},
+	{"strpool", "strpool.Pool 分级复用", strPooled},
+}
+
+// Piece 一种拼接片段
+type Piece struct {
+	Name  string // 基准测试中的名字
+	Desc  string
+	Value string
+}
+
+// Pieces 参与对比的片段：单字节、16 字节 ASCII、多字节中文
+var Pieces = []Piece{
+	{"byte", "单字节 \"a\"", "a"},
+	{"ascii16", "16 字节 ASCII", "hello, gopher!!!"},
+	{"cjk", "中文 \"Go语言编程\"（14 字节）", "Go语言编程"},
+}
+
+// Counts 参与对比的片段个数
+var Counts = []int{10, 100, 1000}
+
+// Repeat 返回 n 个相同片段组成的切片，作为拼接的输入
+func Repeat(p string, n int) []string {
+	pieces := make([]string, n)
+	for i := range pieces {
+		pieces[i] = p
+	}
+	return pieces
+}
+
+// 每次 += 都分配一个新字符串并拷贝之前的全部内容，总拷贝量随片段数平方增长
+func plus(pieces []string) string {
+	s := ""
+	for _, p := range pieces {
+		s += p
+	}
+	return s
+}
+
+// 和 += 一样每次都生成新字符串，还要额外解析格式串、把参数装箱成 interface
+func sprintf(pieces []string) string {
+	s := ""
+	for _, p := range pieces {
+		s = fmt.Sprintf("%s%s", s, p)
+	}
+	return s
+}
+
+// 先算出总长度，只分配一次
+func join(pieces []string) string {
+	return strings.Join(pieces, "")
+}
+
+// 缓冲区按需倍增，最后 String() 再拷贝一次
+func buffer(pieces []string) string {
+	var b bytes.Buffer
+	for _, p := range pieces {
+		b.WriteString(p)
+	}
+	return b.String()
+}
+
+// 缓冲区按需倍增，String() 直接复用底层字节，不再拷贝
+func builder(pieces []string) string {
+	var b strings.Builder
+	for _, p := range pieces {
+		b.WriteString(p)
+	}
+	return b.String()
+}
+
+// 预先 Grow 到总长度，只分配一次
+func builderGrow(pieces []string) string {
+	n := 0
+	for _, p := range pieces {
+		n += len(p)
+	}
+	var b strings.Builder
+	b.Grow(n)
+	for _, p := range pieces {
+		b.WriteString(p)
+	}
+	return b.String()
+}
+
+var bufPool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// 缓冲区从池中复用，只有最后转成字符串时分配一次
+func pooled(pieces []string) string {
+	b := bufPool.Get().(*bytes.Buffer)
+	b.Reset()
+	for _, p := range pieces {
+		b.WriteString(p)
+	}
+	s := b.String()
+	bufPool.Put(b)
+	return s
+}
+
+var builderPool strpool.Pool
+
+// 按预期长度取出已扩容好的 Builder，String() 拷贝一次后归还
+func strPooled(pieces []string) string {
+	n := 0
+	for _, p := range pieces {
+		n += len(p)
+	}
+	b := builderPool.Get(n)
+	for _, p := range pieces {
+		b.WriteString(p)
+	}
+	s := b.String()
+	builderPool.Put(b)
+	return s
+}
@@ -0,0 +1,70 @@
+package concat
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var sink string
+
+func TestStrategies(t *testing.T) {
+	for _, p := range Pieces {
+		for _, n := range Counts {
+			want := strings.Repeat(p.Value, n)
+			for _, s := range Strategies {
+				if got := s.Concat(Repeat(p.Value, n)); got != want {
+					t.Errorf("%s 拼接 %d 个 %q 的结果不正确", s.Name, n, p.Value)
+				}
+			}
+		}
+	}
+}
+
+func TestParseBench(t *testing.T) {
+	out := `goos: linux
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkConcat/cjk/1000/plus-8         	     826	   1464100 ns/op	 7296001 B/op	     999 allocs/op
+BenchmarkConcat/cjk/1000/builder_grow-8 	  105462	     11280 ns/op	   14336 B/op	       1 allocs/op
+PASS
+`
+	results, err := ParseBench(strings.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Result{
+		{Piece: "cjk", Count: 1000, Strategy: "plus", NsPerOp: 1464100, BytesPerOp: 7296001, AllocsPerOp: 999},
+		{Piece: "cjk", Count: 1000, Strategy: "builder_grow", NsPerOp: 11280, BytesPerOp: 14336, AllocsPerOp: 1},
+	}
+	if fmt.Sprint(results) != fmt.Sprint(want) {
+		t.Fatalf("ParseBench = %v，期望 %v", results, want)
+	}
+
+	sum := Summary(results)
+	for _, s := range []string{"1.46ms / 999次", "11.3µs / 1次", "129.8倍"} {
+		if !strings.Contains(sum, s) {
+			t.Errorf("Summary 中没有 %q：\n%s", s, sum)
+		}
+	}
+
+	if _, err := ParseBench(strings.NewReader("BenchmarkConcat/cjk-8 1 1 ns/op 0 B/op 0 allocs/op")); err == nil {
+		t.Error("名字缺少片段个数时应当报错")
+	}
+}
+
+// BenchmarkConcat 名字格式为 BenchmarkConcat/<片段>/<个数>/<拼接方式>，gen.go 按此解析
+func BenchmarkConcat(b *testing.B) {
+	for _, p := range Pieces {
+		for _, n := range Counts {
+			pieces := Repeat(p.Value, n)
+			for _, s := range Strategies {
+				b.Run(fmt.Sprintf("%s/%d/%s", p.Name, n, s.Name), func(b *testing.B) {
+					b.ReportAllocs()
+					for i := 0; i < b.N; i++ {
+						sink = s.Concat(pieces)
+					}
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+//go:build ignore
+
+// gen 运行 BenchmarkConcat，把结果汇总成对照表，供 2_string 课程直接打印。
+//
+//	go generate ./concat
+//	go run gen.go -benchtime 1s -o ../basic/concat_bench.txt
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/colayear/go_learning/concat"
+)
+
+var (
+	output    = flag.String("o", "concat_bench.txt", "输出文件")
+	benchtime = flag.String("benchtime", "200ms", "每个基准测试的运行时间，传给 go test -benchtime")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gen: ")
+	flag.Parse()
+
+	cmd := exec.Command("go", "test", "-run", "^$", "-bench", "^BenchmarkConcat$", "-benchmem", "-benchtime", *benchtime, ".")
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		log.Fatalf("go test 失败：%v\n%s", err, out)
+	}
+	results, err := concat.ParseBench(bytes.NewReader(out))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(results) == 0 {
+		log.Fatalf("没有找到 BenchmarkConcat 的结果：\n%s", out)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "字符串拼接实测（go generate ./concat 生成，%s，%s/%s", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	if cpu := cpuName(out); cpu != "" {
+		fmt.Fprintf(&b, "，%s", cpu)
+	}
+	b.WriteString("）\n单元格为“每次拼接耗时 / 内存分配次数”\n\n")
+	b.WriteString(concat.Summary(results))
+	if err := os.WriteFile(*output, []byte(b.String()), 0o644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("已生成 %s：%d 条结果", *output, len(results))
+}
+
+func cpuName(out []byte) string {
+	for _, line := range strings.Split(string(out), "\n") {
+		if cpu, ok := strings.CutPrefix(line, "cpu: "); ok {
+			return strings.TrimSpace(cpu)
+		}
+	}
+	return ""
+}
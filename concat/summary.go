@@ -0,0 +1,147 @@
+package concat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/colayear/go_learning/strutil"
+)
+
+// Result 一条 BenchmarkConcat 的结果
+type Result struct {
+	Piece       string // 片段名，如 "cjk"
+	Count       int    // 片段个数
+	Strategy    string // 拼接方式名，如 "builder"
+	NsPerOp     float64
+	BytesPerOp  int64
+	AllocsPerOp int64
+}
+
+// ParseBench 从 go test -bench -benchmem 的输出中解析 BenchmarkConcat 的结果，其余行忽略。
+// 结果行形如：
+//
+//	BenchmarkConcat/cjk/100/builder-8   123456   987.6 ns/op   4096 B/op   8 allocs/op
+func ParseBench(r io.Reader) ([]Result, error) {
+	var results []Result
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 8 || !strings.HasPrefix(fields[0], "BenchmarkConcat/") {
+			continue
+		}
+		name := fields[0]
+		if i := strings.LastIndexByte(name, '-'); i > 0 { // 去掉 GOMAXPROCS 后缀
+			name = name[:i]
+		}
+		parts := strings.Split(name, "/")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("concat: 无法解析基准测试名 %q", fields[0])
+		}
+		count, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("concat: 无法解析基准测试名 %q", fields[0])
+		}
+		res := Result{Piece: parts[1], Count: count, Strategy: parts[3]}
+		for i := 2; i+1 < len(fields); i += 2 {
+			v, unit := fields[i], fields[i+1]
+			switch unit {
+			case "ns/op":
+				res.NsPerOp, err = strconv.ParseFloat(v, 64)
+			case "B/op":
+				res.BytesPerOp, err = strconv.ParseInt(v, 10, 64)
+			case "allocs/op":
+				res.AllocsPerOp, err = strconv.ParseInt(v, 10, 64)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("concat: %q 中的 %s 无法解析：%w", fields[0], unit, err)
+			}
+		}
+		results = append(results, res)
+	}
+	return results, sc.Err()
+}
+
+// Summary 把结果渲染成对照表：每种片段一张表，行为拼接方式，列为片段个数，
+// 单元格为“每次耗时 / 内存分配次数”，最后一列是片段最多时相对 += 的倍数
+func Summary(results []Result) string {
+	byKey := map[string]Result{}
+	for _, r := range results {
+		byKey[key(r.Piece, r.Count, r.Strategy)] = r
+	}
+	maxCount := Counts[len(Counts)-1]
+
+	var b strings.Builder
+	for _, p := range Pieces {
+		header := []string{"拼接方式"}
+		for _, n := range Counts {
+			header = append(header, fmt.Sprintf("%d 个", n))
+		}
+		header = append(header, fmt.Sprintf("%d 个时比 += 快", maxCount))
+		rows := [][]string{header}
+
+		base, hasBase := byKey[key(p.Name, maxCount, "plus")]
+		for _, s := range Strategies {
+			row := []string{s.Desc}
+			for _, n := range Counts {
+				r, ok := byKey[key(p.Name, n, s.Name)]
+				if !ok {
+					row = append(row, "-")
+					continue
+				}
+				row = append(row, fmt.Sprintf("%s / %d次", duration(r.NsPerOp), r.AllocsPerOp))
+			}
+			if r, ok := byKey[key(p.Name, maxCount, s.Name)]; ok && hasBase && r.NsPerOp > 0 {
+				row = append(row, fmt.Sprintf("%.1f倍", base.NsPerOp/r.NsPerOp))
+			} else {
+				row = append(row, "-")
+			}
+			rows = append(rows, row)
+		}
+		fmt.Fprintf(&b, "片段：%s\n", p.Desc)
+		writeTable(&b, rows)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func key(piece string, count int, strategy string) string {
+	return fmt.Sprintf("%s/%d/%s", piece, count, strategy)
+}
+
+func duration(ns float64) string {
+	switch {
+	case ns < 1e3:
+		return fmt.Sprintf("%.0fns", ns)
+	case ns < 1e6:
+		return fmt.Sprintf("%.1fµs", ns/1e3)
+	case ns < 1e9:
+		return fmt.Sprintf("%.2fms", ns/1e6)
+	}
+	return fmt.Sprintf("%.2fs", ns/1e9)
+}
+
+// writeTable 按显示宽度对齐输出 Markdown 表格，中文列也能对齐
+func writeTable(w io.Writer, rows [][]string) {
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], strutil.Width(cell))
+		}
+	}
+	for n, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = strutil.PadRight(cell, widths[i])
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+		if n == 0 {
+			for i := range cells {
+				cells[i] = strings.Repeat("-", widths[i])
+			}
+			fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+		}
+	}
+}
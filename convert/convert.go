@@ -0,0 +1,296 @@
+// Package convert 把字符串转换为数字或布尔值，失败时返回可以直接展示给用户的结构化错误。
+//
+// strconv.Atoi("abc123") 的错误只有一句 `strconv.Atoi: parsing "abc123": invalid syntax`，
+// 看不出错在哪里、应该怎么改。本包的 *Error 带有出错位置（字节下标和字符下标）、目标类型、
+// 错误种类（语法或范围）以及修改建议：
+//
+//	convert: "abc123" 转换为 int 失败：第 1 个字符处的 'a' 不是数字，是不是想输入 "123"？
+//
+// 解析规则与 lenient.Default 相同：允许首尾空白、千位分隔符、下划线和全角字符。
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/colayear/go_learning/lenient"
+	"github.com/colayear/go_learning/strutil"
+)
+
+// Value 可以转换的目标类型
+type Value interface {
+	int | int8 | int16 | int32 | int64 |
+		uint | uint8 | uint16 | uint32 | uint64 |
+		float32 | float64 | bool
+}
+
+// Kind 错误种类
+type Kind int
+
+const (
+	Syntax Kind = iota // 不是合法的数字或布尔值
+	Range              // 超出目标类型的范围
+)
+
+func (k Kind) String() string {
+	if k == Range {
+		return "范围错误"
+	}
+	return "语法错误"
+}
+
+// Error 转换失败的详细信息，用 errors.As 取出
+type Error struct {
+	Input      string // 原始输入
+	Type       string // 目标类型，如 "int8"
+	Kind       Kind
+	Offset     int    // 第一个出错字符在 Input 中的字节下标
+	RuneOffset int    // 第一个出错字符是 Input 中的第几个字符（从 0 开始）
+	Reason     string // 出错原因，如 "的 'a' 不是数字"
+	Suggestion string // 建议的输入，如 "123"；没有建议时为空
+	Err        error  // 底层错误，errors.Is(err, strconv.ErrSyntax) 等判断可以穿透
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("convert: %q 转换为 %s 失败：第 %d 个字符处%s", e.Input, e.Type, e.RuneOffset+1, e.Reason)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf("，是不是想输入 %q？", e.Suggestion)
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Pointer 返回两行文本：原始输入，以及指向出错字符的 "^"。
+// 按终端显示宽度对齐，输入中有中文或全角字符时同样指得准。
+func (e *Error) Pointer() string {
+	return e.Input + "\n" + strings.Repeat(" ", strutil.Width(e.Input[:e.Offset])) + "^"
+}
+
+// Parse 把 s 转换为 T，失败时返回 *Error
+func Parse[T Value](s string) (T, error) {
+	var v T
+	var err error
+	switch p := any(&v).(type) {
+	case *int:
+		*p, err = parseInt[int](s, strconv.IntSize)
+	case *int8:
+		*p, err = parseInt[int8](s, 8)
+	case *int16:
+		*p, err = parseInt[int16](s, 16)
+	case *int32:
+		*p, err = parseInt[int32](s, 32)
+	case *int64:
+		*p, err = parseInt[int64](s, 64)
+	case *uint:
+		*p, err = parseUint[uint](s, strconv.IntSize)
+	case *uint8:
+		*p, err = parseUint[uint8](s, 8)
+	case *uint16:
+		*p, err = parseUint[uint16](s, 16)
+	case *uint32:
+		*p, err = parseUint[uint32](s, 32)
+	case *uint64:
+		*p, err = parseUint[uint64](s, 64)
+	case *float32:
+		var f float64
+		f, err = lenient.ParseFloat(s, 32)
+		*p = float32(f)
+	case *float64:
+		*p, err = lenient.ParseFloat(s, 64)
+	case *bool:
+		*p, err = lenient.ParseBool(s)
+	}
+	if err != nil {
+		var zero T
+		return zero, newError(s, fmt.Sprintf("%T", v), err)
+	}
+	return v, nil
+}
+
+// Int 等价于 Parse[int]，对应 strconv.Atoi
+func Int(s string) (int, error) { return Parse[int](s) }
+
+func parseInt[T int | int8 | int16 | int32 | int64](s string, bitSize int) (T, error) {
+	n, err := lenient.ParseInt(s, bitSize)
+	return T(n), err
+}
+
+func parseUint[T uint | uint8 | uint16 | uint32 | uint64](s string, bitSize int) (T, error) {
+	n, err := lenient.ParseUint(s, bitSize)
+	return T(n), err
+}
+
+func newError(s, typ string, err error) error {
+	var le *lenient.Error
+	if !errors.As(err, &le) {
+		return err
+	}
+	e := &Error{
+		Input:      s,
+		Type:       typ,
+		Offset:     le.Offset,
+		RuneOffset: utf8.RuneCountInString(s[:le.Offset]),
+		Reason:     le.Reason,
+		Err:        err,
+	}
+	if le.Rule == lenient.RuleRange {
+		e.Kind = Range
+		e.Reason = fmt.Sprintf("开始的数值超出 %s 的范围 [%s, %s]", typ, minOf(typ), maxOf(typ))
+		if strings.HasPrefix(strings.TrimSpace(s), "-") || strings.HasPrefix(strings.TrimSpace(s), "－") {
+			e.Suggestion = minOf(typ)
+		} else {
+			e.Suggestion = maxOf(typ)
+		}
+		return e
+	}
+	e.Suggestion = suggest(s, typ)
+	return e
+}
+
+// suggest 猜测用户想输入的值：布尔值找拼写最接近的词，数字去掉前后无关的字符后能解析就作为建议。
+// 数字被其他字符隔开时（如整数 "1.5"、"1e3"、"12-34"）猜不出原意，不给建议，以免建议的值与输入不符。
+func suggest(s, typ string) string {
+	if typ == "bool" {
+		return closestWord(strings.ToLower(strings.TrimSpace(s)))
+	}
+	isFloat := strings.HasPrefix(typ, "float")
+	var b strings.Builder
+	started, ended := false, false // 是否已经遇到数字、数字是否已经结束
+	dot, exp := false, false
+	last := rune(0) // 上一个写入的字符
+	for _, r := range s {
+		switch r {
+		case '－':
+			r = '-'
+		case '．':
+			r = '.'
+		}
+		if '０' <= r && r <= '９' {
+			r = r - '０' + '0'
+		}
+		switch {
+		case '0' <= r && r <= '9':
+			if ended {
+				return ""
+			}
+			started = true
+		case ended:
+			continue
+		case !started:
+			// 数字之前只保留紧挨着数字的负号
+			if r != '-' || b.Len() > 0 || strings.HasPrefix(typ, "uint") {
+				continue
+			}
+		case isFloat && r == '.' && !dot && !exp:
+			dot = true
+		case isFloat && (r == 'e' || r == 'E') && !exp:
+			exp, r = true, 'e'
+		case isFloat && (r == '-' || r == '+') && last == 'e':
+		default:
+			ended = true
+			continue
+		}
+		b.WriteRune(r)
+		last = r
+	}
+	guess := b.String()
+	if guess == "" || guess == s {
+		return ""
+	}
+	if _, err := lenient.ParseFloat(guess, 64); err != nil {
+		return ""
+	}
+	// 整数的建议同样需要在目标类型的范围内
+	if !isFloat && !fits(guess, typ) {
+		return ""
+	}
+	return guess
+}
+
+func fits(s, typ string) bool {
+	if strings.HasPrefix(typ, "uint") {
+		_, err := strconv.ParseUint(s, 10, bits(typ))
+		return err == nil
+	}
+	_, err := strconv.ParseInt(s, 10, bits(typ))
+	return err == nil
+}
+
+// closestWord 布尔词表中编辑距离不超过 2 的最接近的词
+func closestWord(s string) string {
+	best, bestDist := "", 3
+	for _, words := range [][]string{lenient.DefaultTrue, lenient.DefaultFalse} {
+		for _, w := range words {
+			if utf8.RuneCountInString(w) < 2 { // "t"、"1" 这样的短词和任何短输入都很接近，不作为建议
+				continue
+			}
+			if d := distance(s, w); d < bestDist {
+				best, bestDist = w, d
+			}
+		}
+	}
+	return best
+}
+
+// distance 按字符计算的编辑距离（Levenshtein），交换相邻字符算一次编辑
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
+
+// bits 类型名对应的位数，int、uint 为平台字长
+func bits(typ string) int {
+	n := strings.TrimLeft(typ, "intuflao")
+	if n == "" {
+		return strconv.IntSize
+	}
+	b, _ := strconv.Atoi(n)
+	return b
+}
+
+func minOf(typ string) string {
+	switch {
+	case strings.HasPrefix(typ, "uint"):
+		return "0"
+	case strings.HasPrefix(typ, "float"):
+		return "-" + maxOf(typ)
+	}
+	return strconv.FormatInt(math.MinInt64>>(64-bits(typ)), 10)
+}
+
+func maxOf(typ string) string {
+	switch {
+	case strings.HasPrefix(typ, "uint"):
+		return strconv.FormatUint(math.MaxUint64>>(64-bits(typ)), 10)
+	case typ == "float32":
+		return strconv.FormatFloat(math.MaxFloat32, 'g', -1, 32)
+	case typ == "float64":
+		return strconv.FormatFloat(math.MaxFloat64, 'g', -1, 64)
+	}
+	return strconv.FormatInt(math.MaxInt64>>(64-bits(typ)), 10)
+}
@@ -0,0 +1,121 @@
+package convert
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	if n, err := Int(" 1,024 "); err != nil || n != 1024 {
+		t.Errorf("Int = %d, %v", n, err)
+	}
+	if n, err := Parse[uint8]("２５５"); err != nil || n != 255 {
+		t.Errorf("Parse[uint8] = %d, %v", n, err)
+	}
+	if f, err := Parse[float32]("0.5"); err != nil || f != 0.5 {
+		t.Errorf("Parse[float32] = %v, %v", f, err)
+	}
+	if b, err := Parse[bool]("是"); err != nil || !b {
+		t.Errorf("Parse[bool] = %t, %v", b, err)
+	}
+}
+
+func TestError(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		parse    func() error
+		want     Error
+		sentinel error
+	}{
+		{"前缀字母", func() error { _, err := Int("abc123"); return err },
+			Error{Input: "abc123", Type: "int", Kind: Syntax, Offset: 0, RuneOffset: 0, Suggestion: "123"}, strconv.ErrSyntax},
+		{"中文单位", func() error { _, err := Parse[float64]("3.14元"); return err },
+			Error{Input: "3.14元", Type: "float64", Kind: Syntax, Offset: 4, RuneOffset: 4, Suggestion: "3.14"}, strconv.ErrSyntax},
+		{"中文前缀", func() error { _, err := Parse[int64]("约１２"); return err },
+			Error{Input: "约１２", Type: "int64", Kind: Syntax, Offset: 0, RuneOffset: 0, Suggestion: "12"}, strconv.ErrSyntax},
+		{"中间的中文", func() error { _, err := Int("１２三4"); return err },
+			Error{Input: "１２三4", Type: "int", Kind: Syntax, Offset: 6, RuneOffset: 2}, strconv.ErrSyntax},
+		{"上溢", func() error { _, err := Parse[int8]("300"); return err },
+			Error{Input: "300", Type: "int8", Kind: Range, Offset: 0, RuneOffset: 0, Suggestion: "127"}, strconv.ErrRange},
+		{"下溢", func() error { _, err := Parse[int16](" -40000"); return err },
+			Error{Input: " -40000", Type: "int16", Kind: Range, Offset: 1, RuneOffset: 1, Suggestion: "-32768"}, strconv.ErrRange},
+		{"负的无符号数", func() error { _, err := Parse[uint32]("-5"); return err },
+			Error{Input: "-5", Type: "uint32", Kind: Syntax, Offset: 0, RuneOffset: 0, Suggestion: "5"}, strconv.ErrSyntax},
+		{"建议也溢出", func() error { _, err := Parse[uint8]("x999"); return err },
+			Error{Input: "x999", Type: "uint8", Kind: Syntax, Offset: 0, RuneOffset: 0}, strconv.ErrSyntax},
+		{"拼写错误", func() error { _, err := Parse[bool]("ture"); return err },
+			Error{Input: "ture", Type: "bool", Kind: Syntax, Offset: 0, RuneOffset: 0, Suggestion: "true"}, strconv.ErrSyntax},
+		{"无法猜测", func() error { _, err := Parse[bool]("maybe"); return err },
+			Error{Input: "maybe", Type: "bool", Kind: Syntax, Offset: 0, RuneOffset: 0}, strconv.ErrSyntax},
+	} {
+		err := tt.parse()
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Errorf("%s：%v 不是 *Error", tt.name, err)
+			continue
+		}
+		if e.Input != tt.want.Input || e.Type != tt.want.Type || e.Kind != tt.want.Kind ||
+			e.Offset != tt.want.Offset || e.RuneOffset != tt.want.RuneOffset || e.Suggestion != tt.want.Suggestion {
+			t.Errorf("%s：得到 %+v\n期望 %+v", tt.name, *e, tt.want)
+		}
+		if !errors.Is(err, tt.sentinel) {
+			t.Errorf("%s：errors.Is(err, %v) 为 false", tt.name, tt.sentinel)
+		}
+	}
+}
+
+// 建议只去掉数字前后的字符，数字被隔开时不给建议，建议的值不能与输入的本意不同
+func TestSuggest(t *testing.T) {
+	for _, tt := range []struct {
+		s, typ, want string
+	}{
+		{"1.5", "int", ""},
+		{"1e3", "int", ""},
+		{"-1.5", "int", ""},
+		{"3.14元", "int", ""},
+		{"12-34", "int", ""},
+		{"1,000", "int", ""},
+		{"12元", "int", "12"},
+		{"约－１２", "int64", "-12"},
+		{"-5", "uint32", "5"},
+		{"3.14元", "float64", "3.14"},
+		{"-1.5元", "float64", "-1.5"},
+		{"1e3元", "float64", "1e3"},
+		{"1.5e-3秒", "float32", "1.5e-3"},
+		{"1.2.3", "float64", ""},
+	} {
+		if got := suggest(tt.s, tt.typ); got != tt.want {
+			t.Errorf("suggest(%q, %s) = %q，期望 %q", tt.s, tt.typ, got, tt.want)
+		}
+	}
+}
+
+func TestPointer(t *testing.T) {
+	_, err := Int("价格１２x")
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatal(err)
+	}
+	if want := "价格１２x\n^"; e.Pointer() != want {
+		t.Errorf("Pointer() = %q，期望 %q", e.Pointer(), want)
+	}
+	_, err = Int("１２x")
+	errors.As(err, &e)
+	if want := "１２x\n    ^"; e.Pointer() != want {
+		t.Errorf("Pointer() = %q，期望 %q", e.Pointer(), want)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"", "yes", 3}, {"ture", "true", 1}, {"yse", "yes", 1}, {"flase", "false", 1}, {"of", "off", 1}, {"是的", "是", 1},
+	} {
+		if got := distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("distance(%q, %q) = %d，期望 %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Package crossarch 用另一个 GOARCH 编译并运行课程，与本机的输出逐行对比。
+//
+// 3_int_float.go 说 int 在 32 位系统上占 4 字节，但在 amd64 上永远看不到。
+// x86-64 的 Linux 可以直接运行 GOARCH=386 的程序，于是可以把同一节课编译两份，
+// 对比 int、uint 的取值范围和 unsafe.Sizeof 的结果在两种架构上的差别：
+//
+//	golearn arch 3_int_float
+package crossarch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+
+	"github.com/colayear/go_learning/lesson"
+)
+
+// runner 课程运行器的包路径，交叉编译后用它的 run 命令运行课程
+const runner = "github.com/colayear/go_learning/cmd/golearn"
+
+// ErrCannotRun 当前系统无法运行该架构的程序（如在 arm64 上运行 386 程序）
+var ErrCannotRun = errors.New("crossarch: 当前系统无法运行该架构的程序")
+
+// Run 在 dir 所在的模块中用 GOARCH=goarch 编译课程运行器，运行 golearn run <paths...>，返回标准输出。
+// 输出经过 lesson.Normalize，去掉了每次运行都会变化的内容。
+func Run(dir, goarch string, paths ...string) (string, error) {
+	tmp, err := os.MkdirTemp("", "crossarch")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+
+	bin := filepath.Join(tmp, "golearn-"+goarch)
+	build := exec.Command("go", "build", "-o", bin, runner)
+	build.Dir = dir
+	build.Env = append(os.Environ(), "GOARCH="+goarch, "CGO_ENABLED=0")
+	if out, err := build.CombinedOutput(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("crossarch: GOARCH=%s 编译失败：\n%s", goarch, out)
+		}
+		return "", fmt.Errorf("crossarch: 无法运行 go build：%w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(bin, append([]string{"run"}, paths...)...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, syscall.ENOEXEC) {
+			return "", fmt.Errorf("%w：GOARCH=%s", ErrCannotRun, goarch)
+		}
+		return "", fmt.Errorf("crossarch: GOARCH=%s 运行失败：%v\n%s", goarch, err, stderr.Bytes())
+	}
+	return lesson.Normalize(stdout.String()), nil
+}
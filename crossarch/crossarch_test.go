@@ -0,0 +1,96 @@
+package crossarch
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	left := []string{"a", "int 占用字节：8", "b", "only left", "c"}
+	right := []string{"a", "int 占用字节：4", "b", "c", "only right"}
+	want := []Line{
+		{Same, "a", "a", 1, 1},
+		{Changed, "int 占用字节：8", "int 占用字节：4", 2, 2},
+		{Same, "b", "b", 3, 3},
+		{Removed, "only left", "", 4, 0},
+		{Same, "c", "c", 5, 4},
+		{Added, "", "only right", 0, 5},
+	}
+	got := Diff(left, right)
+	if len(got) != len(want) {
+		t.Fatalf("Diff = %v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("第 %d 行 = %+v，期望 %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	for _, tt := range []struct{ a, b, wantA, wantB string }{
+		{"占用字节：8", "占用字节：4", "占用字节：«8»", "占用字节：«4»"},
+		{"int 在 amd64 上占 8 字节", "int 在 386 上占 4 字节", "int 在 «amd64» 上占 «8» 字节", "int 在 «386» 上占 «4» 字节"},
+		{"范围 -9223372036854775808 ~ 9223372036854775807", "范围 -2147483648 ~ 2147483647",
+			"范围 -«9223372036854775808» ~ «9223372036854775807»", "范围 -«2147483648» ~ «2147483647»"},
+		{"相同", "相同", "相同", "相同"},
+		{"a b", "a", "a« b»", "a"},
+	} {
+		if a, b := Highlight(tt.a, tt.b); a != tt.wantA || b != tt.wantB {
+			t.Errorf("Highlight(%q, %q) = %q, %q", tt.a, tt.b, a, b)
+		}
+	}
+}
+
+func TestWriteSideBySide(t *testing.T) {
+	var b strings.Builder
+	lines := Diff([]string{"same", "字节：8"}, []string{"same", "字节：4"})
+	WriteSideBySide(&b, "amd64", "386", lines, 12, true)
+	want := "      amd64                386\n" +
+		"    2 字节：«8»    ≠     2 字节：«4»\n"
+	if b.String() != want {
+		t.Errorf("输出为\n%s期望\n%s", b.String(), want)
+	}
+}
+
+// 列宽很窄时每一侧只剩省略号，不能因为截断出错
+func TestWriteSideBySideNarrow(t *testing.T) {
+	lines := Diff([]string{"same", "字节：8"}, []string{"same", "字节：4"})
+	for _, tt := range []struct {
+		width int
+		want  string
+	}{
+		{1, "      …         386\n    2 … ≠     2 …\n"},
+		{2, "      a…         386\n    2 …  ≠     2 …\n"},
+		{0, "               386\n    2  ≠     2 \n"},
+	} {
+		var b strings.Builder
+		WriteSideBySide(&b, "amd64", "386", lines, tt.width, true)
+		if b.String() != tt.want {
+			t.Errorf("宽度 %d 的输出为\n%q\n期望\n%q", tt.width, b.String(), tt.want)
+		}
+	}
+}
+
+// TestRun 交叉编译为 386 运行 3_int_float，int 的大小应当是 4 字节。只在能直接运行 386 程序的 x86-64 Linux 上运行。
+func TestRun(t *testing.T) {
+	if testing.Short() || runtime.GOOS != "linux" || runtime.GOARCH != "amd64" {
+		t.Skip("需要 x86-64 Linux")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("找不到 go 命令")
+	}
+	out, err := Run(".", "386", "3_int_float")
+	if errors.Is(err, ErrCannotRun) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "int 类型 - 值：100，占用字节：4") {
+		t.Errorf("386 上的输出中没有 4 字节的 int：\n%s", out)
+	}
+}
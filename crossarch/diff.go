@@ -0,0 +1,174 @@
+package crossarch
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/colayear/go_learning/strutil"
+)
+
+// Op 一行在两侧输出中的情况
+type Op int
+
+const (
+	Same    Op = iota // 两侧相同
+	Changed           // 两侧都有，内容不同
+	Removed           // 只有左侧有
+	Added             // 只有右侧有
+)
+
+// Line 对比结果中的一行。Left、Right 为空且对应的行号为 0 表示该侧没有这一行
+type Line struct {
+	Op              Op
+	Left, Right     string
+	LeftNo, RightNo int // 从 1 开始的行号
+}
+
+// Diff 按最长公共子序列逐行对比 left 和 right。
+// 相邻的删除和新增两两配对为 Changed，便于并排显示同一行在两种架构上的差别。
+func Diff(left, right []string) []Line {
+	lcs := table(left, right)
+	var lines, removed, added []Line
+	flush := func() {
+		for i := 0; i < max(len(removed), len(added)); i++ {
+			switch {
+			case i < len(removed) && i < len(added):
+				lines = append(lines, Line{Changed, removed[i].Left, added[i].Right, removed[i].LeftNo, added[i].RightNo})
+			case i < len(removed):
+				lines = append(lines, removed[i])
+			default:
+				lines = append(lines, added[i])
+			}
+		}
+		removed, added = removed[:0], added[:0]
+	}
+	i, j := 0, 0
+	for i < len(left) || j < len(right) {
+		switch {
+		case i < len(left) && j < len(right) && left[i] == right[j]:
+			flush()
+			lines = append(lines, Line{Same, left[i], right[j], i + 1, j + 1})
+			i++
+			j++
+		case j == len(right) || i < len(left) && lcs[i+1][j] >= lcs[i][j+1]:
+			removed = append(removed, Line{Op: Removed, Left: left[i], LeftNo: i + 1})
+			i++
+		default:
+			added = append(added, Line{Op: Added, Right: right[j], RightNo: j + 1})
+			j++
+		}
+	}
+	flush()
+	return lines
+}
+
+// table lcs[i][j] 为 a[i:] 与 b[j:] 的最长公共子序列长度
+func table[T comparable](a, b []T) [][]int {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	return lcs
+}
+
+// Highlight 按词元（连续的字母数字，或单个其他字符）对比两行，把不同的部分用 «» 标出：
+//
+//	Highlight("占用字节：8", "占用字节：4")  // "占用字节：«8»", "占用字节：«4»"
+func Highlight(a, b string) (string, string) {
+	ta, tb := tokens(a), tokens(b)
+	lcs := table(ta, tb)
+	var ka, kb []bool // 词元是否属于公共部分
+	i, j := 0, 0
+	for i < len(ta) || j < len(tb) {
+		switch {
+		case i < len(ta) && j < len(tb) && ta[i] == tb[j]:
+			ka, kb = append(ka, true), append(kb, true)
+			i++
+			j++
+		case j == len(tb) || i < len(ta) && lcs[i+1][j] >= lcs[i][j+1]:
+			ka = append(ka, false)
+			i++
+		default:
+			kb = append(kb, false)
+			j++
+		}
+	}
+	return mark(ta, ka), mark(tb, kb)
+}
+
+func tokens(s string) []string {
+	var toks []string
+	for s != "" {
+		n := strings.IndexFunc(s, func(r rune) bool { return !isWord(r) })
+		switch {
+		case n < 0:
+			n = len(s)
+		case n == 0:
+			_, n = utf8.DecodeRuneInString(s)
+		}
+		toks = append(toks, s[:n])
+		s = s[n:]
+	}
+	return toks
+}
+
+func isWord(r rune) bool {
+	return r < 0x80 && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_')
+}
+
+// mark 把连续的非公共词元用 «» 括起来
+func mark(toks []string, keep []bool) string {
+	var b strings.Builder
+	for i, t := range toks {
+		if !keep[i] && (i == 0 || keep[i-1]) {
+			b.WriteString("«")
+		}
+		b.WriteString(t)
+		if !keep[i] && (i == len(toks)-1 || keep[i+1]) {
+			b.WriteString("»")
+		}
+	}
+	return b.String()
+}
+
+// WriteSideBySide 并排输出对比结果：左右各占 width 列，超出部分截断。
+// 不同的行在中间标出 ≠、<、>，并用 Highlight 标出不同的部分；onlyDiff 为 true 时只输出不同的行。
+func WriteSideBySide(w io.Writer, leftTitle, rightTitle string, lines []Line, width int, onlyDiff bool) {
+	fmt.Fprintf(w, "%5s %s   %5s %s\n", "", strutil.Fit(leftTitle, width, "…"), "", rightTitle)
+	for _, l := range lines {
+		if onlyDiff && l.Op == Same {
+			continue
+		}
+		left, right, sep := l.Left, l.Right, "│"
+		switch l.Op {
+		case Changed:
+			left, right = Highlight(l.Left, l.Right)
+			sep = "≠"
+		case Removed:
+			sep = "<"
+		case Added:
+			sep = ">"
+		}
+		fmt.Fprintf(w, "%5s %s %s %5s %s\n", lineNo(l.LeftNo), strutil.Fit(left, width, "…"), sep,
+			lineNo(l.RightNo), strutil.Truncate(right, width, "…"))
+	}
+}
+
+func lineNo(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprint(n)
+}
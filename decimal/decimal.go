@@ -0,0 +1,182 @@
+// Package decimal 任意精度的十进制小数：值为 系数 × 10^-小数位数，系数是 math/big.Int。
+//
+// 3_int_float.go 演示了 0.1 + 0.2 ≠ 0.3：0.1 在二进制中是无限循环小数，float64 只能存近似值。
+// 以分为单位的整数（见 money 包）解决了金额的加减，但 0.0375 这样的利率、
+// 保留 12 位小数的利息中间结果仍然需要十进制小数。Decimal 按字面值精确解析：
+//
+//	x := decimal.MustParse("0.1").Add(decimal.MustParse("0.2"))
+//	x.Equal(decimal.MustParse("0.3")) // true
+//
+// 加、减、乘的结果是精确的，不会舍入；除法和 Round 需要指定小数位数和舍入方式。
+// Context 把小数位数和舍入方式打包，每步运算后按同样的规则舍入。
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// 运算失败的原因，可以用 errors.Is 判断
+var (
+	ErrSyntax         = errors.New("decimal: 格式错误")
+	ErrRange          = errors.New("decimal: 超出范围")
+	ErrDivisionByZero = errors.New("decimal: 除数为 0")
+)
+
+// MaxExponent 解析时指数绝对值的上限，防止 "1e999999999" 这样的输入耗尽内存
+const MaxExponent = 1 << 20
+
+// Decimal 十进制小数，零值为 0。
+// Decimal 是不可变的值，可以直接复制和并发读取，所有运算都返回新的 Decimal。
+type Decimal struct {
+	coef  *big.Int // 系数，为 nil 时表示 0；创建后不再修改，多个 Decimal 可以共享
+	scale int      // 小数位数，总是 ≥ 0
+}
+
+var bigZero = new(big.Int)
+
+// New 系数 × 10^-scale，如 New(375, 4) 为 0.0375。scale 为负数时表示乘以 10 的幂，如 New(12, -3) 为 12000。
+func New(coef int64, scale int) Decimal {
+	return normalize(big.NewInt(coef), scale)
+}
+
+// NewFromBigInt 与 New 相同，系数为 big.Int；coef 会被复制，之后修改 coef 不影响结果
+func NewFromBigInt(coef *big.Int, scale int) Decimal {
+	return normalize(new(big.Int).Set(coef), scale)
+}
+
+// NewFromFloat 把 float64 转换为 Decimal，取能原样转换回 f 的最短十进制表示，
+// 即 NewFromFloat(0.1) 为 0.1，而不是 0.1 在二进制中实际存储的 0.1000000000000000055511151231257827…
+// f 为 NaN 或无穷大时返回 ErrRange。
+func NewFromFloat(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, fmt.Errorf("%w：%v 无法表示为小数", ErrRange, f)
+	}
+	return Parse(strconv.FormatFloat(f, 'e', -1, 64))
+}
+
+// normalize 把小数位数为负的结果转换为小数位数 0；coef 归结果所有
+func normalize(coef *big.Int, scale int) Decimal {
+	if scale < 0 {
+		coef.Mul(coef, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{coef, scale}
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return bigZero
+	}
+	return d.coef
+}
+
+// rescaled 小数位数为 scale（≥ d.scale）时的系数
+func (d Decimal) rescaled(scale int) *big.Int {
+	if scale == d.scale {
+		return d.int()
+	}
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+// Coefficient 系数的副本
+func (d Decimal) Coefficient() *big.Int { return new(big.Int).Set(d.int()) }
+
+// Scale 小数位数。解析时保留末尾的 0："1.50" 的小数位数为 2
+func (d Decimal) Scale() int { return d.scale }
+
+// Sign 符号：负数 -1，0 为 0，正数 1
+func (d Decimal) Sign() int { return d.int().Sign() }
+
+// IsZero 是否为 0
+func (d Decimal) IsZero() bool { return d.Sign() == 0 }
+
+// Neg -d
+func (d Decimal) Neg() Decimal { return Decimal{new(big.Int).Neg(d.int()), d.scale} }
+
+// Abs |d|
+func (d Decimal) Abs() Decimal { return Decimal{new(big.Int).Abs(d.int()), d.scale} }
+
+// Add d + y，结果精确，小数位数取两者中较大的
+func (d Decimal) Add(y Decimal) Decimal {
+	s := max(d.scale, y.scale)
+	return Decimal{new(big.Int).Add(d.rescaled(s), y.rescaled(s)), s}
+}
+
+// Sub d - y，结果精确，小数位数取两者中较大的
+func (d Decimal) Sub(y Decimal) Decimal {
+	s := max(d.scale, y.scale)
+	return Decimal{new(big.Int).Sub(d.rescaled(s), y.rescaled(s)), s}
+}
+
+// Mul d × y，结果精确，小数位数为两者之和
+func (d Decimal) Mul(y Decimal) Decimal {
+	return Decimal{new(big.Int).Mul(d.int(), y.int()), d.scale + y.scale}
+}
+
+// Quo d ÷ y，结果按 mode 舍入到 scale 位小数。scale 为负数时舍入到十位、百位……
+// y 为 0 时返回 ErrDivisionByZero。
+func (d Decimal) Quo(y Decimal, scale int, mode RoundingMode) (Decimal, error) {
+	if y.IsZero() {
+		return Decimal{}, ErrDivisionByZero
+	}
+	// d / y = (d.coef / y.coef) × 10^(y.scale - d.scale)，结果的系数为 d / y × 10^scale
+	n, den := new(big.Int).Set(d.int()), new(big.Int).Set(y.int())
+	if k := scale - d.scale + y.scale; k >= 0 {
+		n.Mul(n, pow10(k))
+	} else {
+		den.Mul(den, pow10(-k))
+	}
+	if den.Sign() < 0 {
+		n.Neg(n)
+		den.Neg(den)
+	}
+	return normalize(mode.div(n, den), scale), nil
+}
+
+// Round 按 mode 舍入到 scale 位小数。scale 比 d 的小数位数大时在末尾补 0，如 "0.3" 舍入到 2 位为 "0.30"
+func (d Decimal) Round(scale int, mode RoundingMode) Decimal {
+	if scale >= d.scale {
+		return Decimal{d.rescaled(scale), scale}
+	}
+	return normalize(mode.div(d.int(), pow10(d.scale-scale)), scale)
+}
+
+// Cmp 比较大小：d < y 返回 -1，相等返回 0，d > y 返回 1。只比较数值，"0.3" 与 "0.30" 相等
+func (d Decimal) Cmp(y Decimal) int {
+	s := max(d.scale, y.scale)
+	return d.rescaled(s).Cmp(y.rescaled(s))
+}
+
+// Equal 数值是否相等，等价于 d.Cmp(y) == 0
+func (d Decimal) Equal(y Decimal) bool { return d.Cmp(y) == 0 }
+
+// Float64 最接近 d 的 float64，exact 表示转换是否没有误差（0.5 是精确的，0.1 不是）
+func (d Decimal) Float64() (f float64, exact bool) {
+	return new(big.Rat).SetFrac(d.int(), pow10(d.scale)).Float64()
+}
+
+// Context 运算的小数位数和舍入方式，如计息时统一保留 12 位小数、银行家舍入
+type Context struct {
+	Scale int
+	Mode  RoundingMode
+}
+
+// Add x + y，按 c 舍入
+func (c Context) Add(x, y Decimal) Decimal { return x.Add(y).Round(c.Scale, c.Mode) }
+
+// Sub x - y，按 c 舍入
+func (c Context) Sub(x, y Decimal) Decimal { return x.Sub(y).Round(c.Scale, c.Mode) }
+
+// Mul x × y，按 c 舍入
+func (c Context) Mul(x, y Decimal) Decimal { return x.Mul(y).Round(c.Scale, c.Mode) }
+
+// Quo x ÷ y，按 c 舍入；y 为 0 时返回 ErrDivisionByZero
+func (c Context) Quo(x, y Decimal) (Decimal, error) { return x.Quo(y, c.Scale, c.Mode) }
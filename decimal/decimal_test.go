@@ -0,0 +1,193 @@
+package decimal
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestParseString(t *testing.T) {
+	for _, tt := range []struct{ in, want string }{
+		{"0", "0"}, {"-0", "0"}, {"0.1", "0.1"}, {"12.50", "12.50"}, {"-12.5", "-12.5"},
+		{".5", "0.5"}, {"5.", "5"}, {"+007", "7"}, {"0.0375", "0.0375"},
+		{"1e3", "1000"}, {"1.5E-3", "0.0015"}, {"-2.50e+1", "-25.0"}, {"123e-5", "0.00123"},
+		{"123456789012345678901234567890.123456789012", "123456789012345678901234567890.123456789012"},
+	} {
+		d, err := Parse(tt.in)
+		if err != nil || d.String() != tt.want {
+			t.Errorf("Parse(%q) = %q, %v，期望 %q", tt.in, d, err, tt.want)
+		}
+	}
+	for _, tt := range []struct {
+		in     string
+		offset int
+		err    error
+	}{
+		{"", 0, ErrSyntax}, {"-", 1, ErrSyntax}, {".", 1, ErrSyntax}, {"1.2.3", 3, ErrSyntax},
+		{"1,000", 1, ErrSyntax}, {" 1", 0, ErrSyntax}, {"e5", 0, ErrSyntax}, {"1e", 2, ErrSyntax},
+		{"1e1.5", 2, ErrSyntax}, {"NaN", 0, ErrSyntax}, {"1e9999999", 2, ErrRange},
+		{"1e99999999999999999999", 2, ErrRange},
+	} {
+		_, err := Parse(tt.in)
+		var e *Error
+		if !errors.As(err, &e) || e.Offset != tt.offset || !errors.Is(err, tt.err) {
+			t.Errorf("Parse(%q)：错误为 %v，期望在第 %d 字节处出现 %v", tt.in, err, tt.offset, tt.err)
+		}
+	}
+	if got := New(375, 4).String(); got != "0.0375" {
+		t.Errorf("New(375, 4) = %q", got)
+	}
+	if got := New(12, -3).String(); got != "12000" {
+		t.Errorf("New(12, -3) = %q", got)
+	}
+	var zero Decimal
+	if zero.String() != "0" || !zero.IsZero() || zero.Add(New(1, 1)).String() != "0.1" {
+		t.Errorf("零值不可用：%q", zero)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	sum := MustParse("0.1").Add(MustParse("0.2"))
+	if !sum.Equal(MustParse("0.3")) || sum.String() != "0.3" {
+		t.Errorf("0.1 + 0.2 = %s", sum)
+	}
+	if got := MustParse("1.00").Sub(MustParse("0.3")); got.String() != "0.70" {
+		t.Errorf("1.00 - 0.3 = %s", got)
+	}
+	if got := MustParse("10000").Mul(MustParse("0.0375")); got.String() != "375.0000" {
+		t.Errorf("10000 × 0.0375 = %s", got)
+	}
+	if MustParse("0.3").Cmp(MustParse("0.30")) != 0 || MustParse("-1").Cmp(MustParse("0.5")) != -1 ||
+		MustParse("2").Cmp(MustParse("1.999")) != 1 {
+		t.Error("Cmp 结果不正确")
+	}
+	if got := MustParse("-1.5").Abs(); got.String() != "1.5" {
+		t.Errorf("Abs = %s", got)
+	}
+	if got := MustParse("1.5").Neg(); got.String() != "-1.5" {
+		t.Errorf("Neg = %s", got)
+	}
+
+	for _, tt := range []struct {
+		x, y  string
+		scale int
+		mode  RoundingMode
+		want  string
+	}{
+		{"1", "3", 12, HalfEven, "0.333333333333"},
+		{"2", "3", 4, HalfUp, "0.6667"}, {"2", "3", 4, Down, "0.6666"},
+		{"-2", "3", 4, HalfUp, "-0.6667"}, {"-2", "3", 4, Ceiling, "-0.6666"}, {"-2", "3", 4, Floor, "-0.6667"},
+		{"1", "-8", 2, HalfEven, "-0.12"}, {"1", "-8", 2, HalfUp, "-0.13"},
+		{"1", "0.04", 0, Down, "25"}, {"12345", "1", -2, HalfUp, "12300"}, {"0.5", "0.25", 3, Up, "2.000"},
+	} {
+		got, err := MustParse(tt.x).Quo(MustParse(tt.y), tt.scale, tt.mode)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("%s ÷ %s（%d 位，%s）= %s, %v，期望 %s", tt.x, tt.y, tt.scale, tt.mode, got, err, tt.want)
+		}
+	}
+	if _, err := MustParse("1").Quo(Decimal{}, 2, HalfUp); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("除以 0：错误为 %v", err)
+	}
+}
+
+func TestRound(t *testing.T) {
+	modes := []RoundingMode{HalfUp, HalfEven, Down, Up, Ceiling, Floor}
+	for _, tt := range []struct {
+		in   string
+		want [6]string // 依次对应 modes
+	}{
+		{"2.5", [6]string{"3", "2", "2", "3", "3", "2"}},
+		{"3.5", [6]string{"4", "4", "3", "4", "4", "3"}},
+		{"-2.5", [6]string{"-3", "-2", "-2", "-3", "-2", "-3"}},
+		{"2.1", [6]string{"2", "2", "2", "3", "3", "2"}},
+		{"-2.9", [6]string{"-3", "-3", "-2", "-3", "-2", "-3"}},
+		{"7", [6]string{"7", "7", "7", "7", "7", "7"}},
+	} {
+		for i, mode := range modes {
+			if got := MustParse(tt.in).Round(0, mode); got.String() != tt.want[i] {
+				t.Errorf("%s 按%s取整 = %s，期望 %s", tt.in, mode, got, tt.want[i])
+			}
+		}
+	}
+	if HalfEven.String() != "银行家舍入" || RoundingMode(-1).String() != "RoundingMode(-1)" || RoundingMode(6).String() != "RoundingMode(6)" {
+		t.Error("RoundingMode.String 结果错误")
+	}
+	if got := MustParse("0.3").Round(2, HalfUp); got.String() != "0.30" {
+		t.Errorf("0.3 保留 2 位 = %s", got)
+	}
+	if got := MustParse("1250").Round(-2, HalfEven); got.String() != "1200" {
+		t.Errorf("1250 舍入到百位 = %s", got)
+	}
+
+	ctx := Context{Scale: 2, Mode: HalfEven}
+	rate := MustParse("0.0375")
+	interest := ctx.Mul(MustParse("1234.56"), rate) // 46.296
+	if interest.String() != "46.30" {
+		t.Errorf("利息 = %s", interest)
+	}
+	if got, _ := ctx.Quo(MustParse("10"), MustParse("3")); got.String() != "3.33" {
+		t.Errorf("10 ÷ 3 = %s", got)
+	}
+	if got := ctx.Add(MustParse("0.125"), MustParse("0")); got.String() != "0.12" {
+		t.Errorf("0.125 保留 2 位 = %s", got)
+	}
+	if got := ctx.Sub(MustParse("1"), MustParse("0.005")); got.String() != "1.00" {
+		t.Errorf("1 - 0.005 保留 2 位 = %s", got)
+	}
+}
+
+func TestFloat(t *testing.T) {
+	d, err := NewFromFloat(0.1)
+	if err != nil || d.String() != "0.1" {
+		t.Errorf("NewFromFloat(0.1) = %s, %v", d, err)
+	}
+	if d, _ := NewFromFloat(-1.5e-7); d.String() != "-0.00000015" {
+		t.Errorf("NewFromFloat(-1.5e-7) = %s", d)
+	}
+	if _, err := NewFromFloat(0 / zeroFloat); !errors.Is(err, ErrRange) {
+		t.Errorf("NewFromFloat(NaN)：错误为 %v", err)
+	}
+	if f, exact := MustParse("0.5").Float64(); f != 0.5 || !exact {
+		t.Errorf("0.5 → %v, %v", f, exact)
+	}
+	if f, exact := MustParse("0.1").Float64(); f != 0.1 || exact {
+		t.Errorf("0.1 → %v, %v", f, exact)
+	}
+	coef := big.NewInt(5)
+	d = NewFromBigInt(coef, 1)
+	coef.SetInt64(7)
+	if d.String() != "0.5" || d.Coefficient().Int64() != 5 || d.Scale() != 1 {
+		t.Errorf("NewFromBigInt 没有复制系数：%s", d)
+	}
+}
+
+var zeroFloat float64
+
+func TestJSON(t *testing.T) {
+	type invoice struct {
+		Amount Decimal  `json:"amount"`
+		Rate   Decimal  `json:"rate"`
+		Fee    *Decimal `json:"fee"`
+	}
+	var v invoice
+	if err := json.Unmarshal([]byte(`{"amount":"1234.50","rate":0.0375,"fee":null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Amount.String() != "1234.50" || v.Rate.String() != "0.0375" || v.Fee != nil {
+		t.Errorf("解码结果：%+v", v)
+	}
+	out, err := json.Marshal(v)
+	if err != nil || string(out) != `{"amount":"1234.50","rate":"0.0375","fee":null}` {
+		t.Errorf("编码结果：%s, %v", out, err)
+	}
+	if err := json.Unmarshal([]byte(`{"amount":"1.2x"}`), &v); !errors.Is(err, ErrSyntax) {
+		t.Errorf("解码无效小数：错误为 %v", err)
+	}
+
+	text, _ := MustParse("-0.05").MarshalText()
+	var d Decimal
+	if err := d.UnmarshalText(text); err != nil || d.String() != "-0.05" {
+		t.Errorf("文本往返：%s, %v", d, err)
+	}
+}
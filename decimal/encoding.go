@@ -0,0 +1,139 @@
+package decimal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Error 解析失败的详细信息
+type Error struct {
+	Input  string
+	Offset int    // 出错位置在 Input 中的字节下标
+	Reason string // 具体原因，如 "的 'x' 不是数字"
+	Err    error  // ErrSyntax 或 ErrRange
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("decimal: 解析 %q 失败：第 %d 字节处%s", e.Input, e.Offset, e.Reason)
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Parse 按字面值精确解析十进制小数，格式与 strconv.ParseFloat 的十进制写法相同：
+// 可选的正负号、整数部分、小数部分，以及可选的指数，如 "0.1"、"-12.50"、".5"、"1.5e-3"。
+// 末尾的 0 会保留在小数位数中，"12.50" 输出时仍为 "12.50"。
+func Parse(s string) (Decimal, error) {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	var digits []byte
+	scale, nd := 0, 0 // nd 为尾数中数字的个数
+mantissa:
+	for dot := false; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			digits = append(digits, c)
+			nd++
+			if dot {
+				scale++
+			}
+		case c == '.' && !dot:
+			dot = true
+		case c == '.':
+			return Decimal{}, syntaxError(s, i, "出现了第二个小数点")
+		case c == 'e' || c == 'E':
+			break mantissa
+		default:
+			return Decimal{}, syntaxError(s, i, fmt.Sprintf("的 %q 不是数字", c))
+		}
+	}
+	if nd == 0 {
+		return Decimal{}, syntaxError(s, i, "缺少数字")
+	}
+	if i < len(s) { // s[i] 为 'e' 或 'E'
+		start := i + 1
+		if start == len(s) {
+			return Decimal{}, syntaxError(s, start, "缺少指数")
+		}
+		exp, err := strconv.Atoi(s[start:])
+		if err != nil && !errors.Is(err, strconv.ErrRange) {
+			return Decimal{}, syntaxError(s, start, fmt.Sprintf("的 %q 不是有效的指数", s[start:]))
+		}
+		if err != nil || exp > MaxExponent || exp < -MaxExponent {
+			return Decimal{}, &Error{Input: s, Offset: start, Reason: fmt.Sprintf("的指数绝对值超过 %d", MaxExponent), Err: ErrRange}
+		}
+		scale -= exp
+	}
+	coef, _ := new(big.Int).SetString(string(digits), 10)
+	if s[0] == '-' {
+		coef.Neg(coef)
+	}
+	return normalize(coef, scale), nil
+}
+
+func syntaxError(s string, offset int, reason string) error {
+	return &Error{Input: s, Offset: offset, Reason: reason, Err: ErrSyntax}
+}
+
+// MustParse 与 Parse 相同，但解析失败时 panic，用于常量
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// String 不带指数的十进制表示，保留全部小数位数，如 "0.30"、"-12.5"、"1000"
+func (d Decimal) String() string {
+	abs := new(big.Int).Abs(d.int()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale == 0 {
+		return sign + abs
+	}
+	if len(abs) <= d.scale {
+		abs = strings.Repeat("0", d.scale-len(abs)+1) + abs
+	}
+	n := len(abs) - d.scale
+	return sign + abs[:n] + "." + abs[n:]
+}
+
+// MarshalText 实现 encoding.TextMarshaler，格式同 String
+func (d Decimal) MarshalText() ([]byte, error) { return []byte(d.String()), nil }
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，格式同 Parse
+func (d *Decimal) UnmarshalText(text []byte) error {
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalJSON 编码为 JSON 字符串，如 "0.30"。
+// 不编码为 JSON 数字，因为 JavaScript 等语言会把数字解析成 float64，又丢掉了精度。
+func (d Decimal) MarshalJSON() ([]byte, error) { return []byte(`"` + d.String() + `"`), nil }
+
+// UnmarshalJSON 接受 JSON 字符串（"0.1"）和 JSON 数字（0.1），数字同样按字面值精确解析。
+// 与标准库的约定一致，null 不修改 d。
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+		if bytes.IndexByte(data, '\\') >= 0 {
+			return fmt.Errorf("%w：JSON 字符串中不应有转义字符", ErrSyntax)
+		}
+	}
+	return d.UnmarshalText(data)
+}
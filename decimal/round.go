@@ -0,0 +1,64 @@
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// RoundingMode 舍入到指定小数位数的方式
+type RoundingMode int
+
+const (
+	HalfUp   RoundingMode = iota // 四舍五入，恰好一半时远离 0：2.5 → 3，-2.5 → -3
+	HalfEven                     // 银行家舍入，恰好一半时取偶数：2.5 → 2，3.5 → 4
+	Down                         // 向 0 截断：2.9 → 2，-2.9 → -2
+	Up                           // 远离 0 进位：2.1 → 3，-2.1 → -3
+	Ceiling                      // 向正无穷取整：2.1 → 3，-2.9 → -2
+	Floor                        // 向负无穷取整：2.9 → 2，-2.1 → -3
+)
+
+var modeNames = [...]string{
+	HalfUp:   "四舍五入",
+	HalfEven: "银行家舍入",
+	Down:     "向零截断",
+	Up:       "远离零进位",
+	Ceiling:  "向上取整",
+	Floor:    "向下取整",
+}
+
+func (r RoundingMode) String() string {
+	if r < 0 || int(r) >= len(modeNames) {
+		return fmt.Sprintf("RoundingMode(%d)", int(r))
+	}
+	return modeNames[r]
+}
+
+// div n / d（d > 0）按舍入方式取整
+func (r RoundingMode) div(n, d *big.Int) *big.Int {
+	q, rem := new(big.Int).QuoRem(n, d, new(big.Int)) // 向 0 截断，rem 与 n 同号
+	if rem.Sign() == 0 {
+		return q
+	}
+	away := false // 是否需要远离 0 调整一位
+	switch r {
+	case Up:
+		away = true
+	case Ceiling:
+		away = n.Sign() > 0
+	case Floor:
+		away = n.Sign() < 0
+	case HalfUp, HalfEven:
+		twice := new(big.Int).Abs(rem)
+		twice.Lsh(twice, 1)
+		switch c := twice.Cmp(d); {
+		case c > 0:
+			away = true
+		case c == 0:
+			away = r == HalfUp || q.Bit(0) == 1
+		}
+	}
+	if away {
+		q.Add(q, big.NewInt(int64(n.Sign())))
+	}
+	return q
+}
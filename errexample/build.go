@@ -0,0 +1,71 @@
+package errexample
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// BuildResult 用真实编译器编译取消注释后的课程得到的结果
+type BuildResult struct {
+	Output string   // go build 的完整输出
+	Errors []string // 示例所在行的报错信息
+}
+
+// 编译器诊断行：./2_string.go:49:2: cannot assign to str4[0] ...
+var diagRe = regexp.MustCompile(`^(.+?\.go):(\d+):\d+: (.*)$`)
+
+// Build 把示例取消注释后写入临时文件，通过 go build -overlay 替换原文件编译所在的包，
+// 仓库中的源文件不会被修改。
+func Build(e Example) (*BuildResult, error) {
+	src, err := os.ReadFile(e.File)
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := os.MkdirTemp("", "golearn-try-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	spliced := filepath.Join(tmp, filepath.Base(e.File))
+	if err := os.WriteFile(spliced, Splice(src, e), 0o644); err != nil {
+		return nil, err
+	}
+	abs, err := filepath.Abs(e.File)
+	if err != nil {
+		return nil, err
+	}
+	overlay, err := json.Marshal(map[string]any{"Replace": map[string]string{abs: spliced}})
+	if err != nil {
+		return nil, err
+	}
+	overlayFile := filepath.Join(tmp, "overlay.json")
+	if err := os.WriteFile(overlayFile, overlay, 0o644); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command("go", "build", "-overlay", overlayFile, "-o", os.DevNull, ".")
+	cmd.Dir = filepath.Dir(abs)
+	out, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, fmt.Errorf("errexample: 无法运行 go build：%w", err)
+	}
+
+	r := &BuildResult{Output: string(out)}
+	for _, line := range strings.Split(r.Output, "\n") {
+		m := diagRe.FindStringSubmatch(line)
+		if m == nil || filepath.Base(m[1]) != filepath.Base(e.File) || m[2] != strconv.Itoa(e.Line) {
+			continue
+		}
+		r.Errors = append(r.Errors, m[3])
+	}
+	return r, nil
+}
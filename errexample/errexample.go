@@ -0,0 +1,268 @@
+// Package errexample 校验课程中被注释掉的“错误示例”。
+//
+// 课程里的错误示例写成一行注释，后面跟着编译器会给出的报错：
+//
+//	// str4[0] = 'g' // 报错：cannot assign to str4[0]
+//
+// Verify 逐条把示例取消注释，放回原文件原位置，用 go/types 对整个包做类型检查，
+// 确认该行确实报错，且报错信息包含注释中声称的内容。
+package errexample
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Example 一条被注释掉的错误示例
+type Example struct {
+	File string // 源文件路径
+	Line int    // 所在行号（从 1 开始）
+	Code string // 被注释掉的代码，如 "str4[0] = 'g'"
+	Want string // 注释声称的编译错误，如 "cannot assign to str4[0]"
+	// Note 示例附近的讲解注释：上方紧邻的注释块，以及下方以“原因”“正确做法”开头的注释
+	Note []string
+}
+
+func (e Example) String() string {
+	return fmt.Sprintf("%s:%d: %s", filepath.Base(e.File), e.Line, e.Code)
+}
+
+// Result 一条错误示例的校验结果
+type Result struct {
+	Example
+	Errors []string // 取消注释后，类型检查在该行报告的所有错误
+}
+
+// OK 该行确实报错，且某条报错包含注释中声称的内容
+func (r Result) OK() bool { return r.Match() >= 0 }
+
+// Match 第一条包含注释中声称的内容的报错在 Errors 中的下标，没有时返回 -1
+func (r Result) Match() int {
+	want := normalize(r.Want)
+	for i, e := range r.Errors {
+		if strings.Contains(normalize(e), want) {
+			return i
+		}
+	}
+	return -1
+}
+
+// 形如 "	// <代码> // 报错：<信息>" 的注释行
+var exampleRe = regexp.MustCompile(`^(\s*)//\s*(.+?)\s*//\s*报错[：:]\s*(.+?)\s*$`)
+
+// Extract 找出源码中所有的错误示例
+func Extract(filename string, src []byte) []Example {
+	var examples []Example
+	lines := strings.Split(string(src), "\n")
+	for i, line := range lines {
+		m := exampleRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		examples = append(examples, Example{
+			File: filename,
+			Line: i + 1,
+			Code: m[2],
+			Want: m[3],
+			Note: note(lines, i),
+		})
+	}
+	return examples
+}
+
+// note 收集第 i 行错误示例附近的讲解注释
+func note(lines []string, i int) []string {
+	var texts []string
+	for j := i - 1; j >= 0; j-- {
+		text, ok := comment(lines[j])
+		if !ok || exampleRe.MatchString(lines[j]) {
+			break
+		}
+		if !strings.Contains(text, "错误示例") { // “错误示例（注释掉…）”只是标记，不算讲解
+			texts = append([]string{text}, texts...)
+		}
+	}
+	for j := i + 1; j < len(lines); j++ {
+		text, ok := comment(lines[j])
+		if !ok || !(strings.HasPrefix(text, "原因") || strings.HasPrefix(text, "正确做法")) {
+			break
+		}
+		texts = append(texts, text)
+	}
+	return texts
+}
+
+// comment 返回单行注释的正文，不是注释行时 ok 为 false
+func comment(line string) (text string, ok bool) {
+	text, ok = strings.CutPrefix(strings.TrimSpace(line), "//")
+	return strings.TrimSpace(text), ok
+}
+
+// Splice 返回把示例取消注释后的源码，行号保持不变
+func Splice(src []byte, e Example) []byte {
+	lines := strings.Split(string(src), "\n")
+	m := exampleRe.FindStringSubmatch(lines[e.Line-1])
+	lines[e.Line-1] = m[1] + m[2] + " // 报错：" + m[3]
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// Verify 校验目录 dir（一个 Go 包，不含测试文件）中的所有错误示例。
+// 依赖包的类型信息在 dir 中用 go list -export 取得，与当前工作目录无关。
+func Verify(dir string) ([]Result, error) {
+	p, err := loadPackage(dir)
+	if err != nil {
+		return nil, err
+	}
+	// 未修改的包本身必须能通过类型检查，否则报错无法归因到示例
+	if errs := p.check("", nil); len(errs) > 0 {
+		return nil, fmt.Errorf("errexample: 包 %s 本身无法通过类型检查：%v", dir, errs[0])
+	}
+
+	var results []Result
+	for _, name := range p.names {
+		for _, e := range Extract(name, p.src[name]) {
+			results = append(results, Result{Example: e, Errors: p.checkSpliced(e)})
+		}
+	}
+	return results, nil
+}
+
+// pkg 一个待检查的包：源码只读一次，标准库等依赖通过同一个 importer 缓存
+type pkg struct {
+	fset     *token.FileSet
+	importer types.Importer
+	names    []string
+	src      map[string][]byte
+	files    map[string]*ast.File
+}
+
+func loadPackage(dir string) (*pkg, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	imp, err := exportImporter(fset, dir)
+	if err != nil {
+		return nil, err
+	}
+	p := &pkg{
+		fset:     fset,
+		importer: imp,
+		src:      map[string][]byte{},
+		files:    map[string]*ast.File{},
+	}
+	for _, name := range matches {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		p.names = append(p.names, name)
+		p.src[name] = src
+		p.files[name] = f
+	}
+	if len(p.names) == 0 {
+		return nil, fmt.Errorf("errexample: 目录 %s 中没有 Go 源文件", dir)
+	}
+	sort.Strings(p.names)
+	return p, nil
+}
+
+// exportImporter 在 dir 中运行 go list -export，按依赖包的导出数据导入类型信息。
+// go 命令在 dir 中运行，依赖按 dir 所在的模块解析。
+func exportImporter(fset *token.FileSet, dir string) (types.Importer, error) {
+	cmd := exec.Command("go", "list", "-e", "-export", "-deps", "-f", "{{.ImportPath}}\t{{.Export}}", ".")
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("errexample: 无法获取 %s 的依赖：\n%s", dir, stderr.Bytes())
+		}
+		return nil, fmt.Errorf("errexample: 无法运行 go list：%w", err)
+	}
+	exports := map[string]string{}
+	for _, line := range strings.Split(string(out), "\n") {
+		if path, file, ok := strings.Cut(line, "\t"); ok && file != "" {
+			exports[path] = file
+		}
+	}
+	return importer.ForCompiler(fset, "gc", func(path string) (io.ReadCloser, error) {
+		file, ok := exports[path]
+		if !ok {
+			return nil, fmt.Errorf("errexample: 找不到包 %s 的导出数据", path)
+		}
+		return os.Open(file)
+	}), nil
+}
+
+// checkSpliced 取消注释示例 e 后检查整个包，返回 e 所在行的报错。
+// 示例本身有语法错误时，语法错误同样算作该行的报错。
+func (p *pkg) checkSpliced(e Example) []string {
+	var msgs []string
+	f, err := parser.ParseFile(p.fset, e.File, Splice(p.src[e.File], e), parser.ParseComments)
+	if list, ok := err.(scanner.ErrorList); ok {
+		for _, se := range list {
+			if se.Pos.Line == e.Line {
+				msgs = append(msgs, se.Msg)
+			}
+		}
+		return msgs
+	}
+	for _, te := range p.check(e.File, f) {
+		if p.fset.Position(te.Pos).Line == e.Line {
+			msgs = append(msgs, te.Msg)
+		}
+	}
+	return msgs
+}
+
+// check 对整个包做类型检查，replace 不为空时用 f 替换该文件
+func (p *pkg) check(replace string, f *ast.File) []types.Error {
+	files := make([]*ast.File, 0, len(p.names))
+	for _, name := range p.names {
+		if name == replace {
+			files = append(files, f)
+		} else {
+			files = append(files, p.files[name])
+		}
+	}
+	var errs []types.Error
+	conf := types.Config{
+		Importer: p.importer,
+		Error: func(err error) {
+			if te, ok := err.(types.Error); ok {
+				errs = append(errs, te)
+			}
+		},
+	}
+	conf.Check(files[0].Name.Name, p.fset, files, nil)
+	return errs
+}
+
+func normalize(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,39 @@
+package errexample
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	src := "package p\n\nfunc f() {\n\ts := \"Go\"\n\t// s[0] = 'g' // 报错：cannot assign to s[0]\n\t// 原因：字符串不可变\n\t_ = s\n}\n"
+	got := Extract("p.go", []byte(src))
+	if len(got) != 1 {
+		t.Fatalf("Extract 找到 %d 条示例，期望 1 条", len(got))
+	}
+	e := got[0]
+	if e.Line != 5 || e.Code != "s[0] = 'g'" || e.Want != "cannot assign to s[0]" ||
+		len(e.Note) != 1 || e.Note[0] != "原因：字符串不可变" {
+		t.Errorf("Extract = %+v", e)
+	}
+	spliced := strings.Split(string(Splice([]byte(src), got[0])), "\n")[4]
+	if spliced != "\ts[0] = 'g' // 报错：cannot assign to s[0]" {
+		t.Errorf("Splice 后第 5 行为 %q", spliced)
+	}
+}
+
+// TestLessons 课程中每一条错误示例都必须真的产生注释所说的编译错误
+func TestLessons(t *testing.T) {
+	results, err := Verify("../basic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) == 0 {
+		t.Fatal("basic/ 中没有找到错误示例")
+	}
+	for _, r := range results {
+		if !r.OK() {
+			t.Errorf("%s\n注释：%s\n实际：%q", r.Example, r.Want, r.Errors)
+		}
+	}
+}
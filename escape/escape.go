@@ -0,0 +1,186 @@
+// Package escape 用编译器的优化诊断（go build -gcflags=-m）说明课程代码中的
+// 逃逸分析和内联结果，并把诊断信息对应回课程中的函数。
+//
+// 例如 6_function.go 中 counter() 的闭包捕获了 count，编译器会报告
+// “moved to heap: count”，说明 count 被分配在堆上，counter 返回后依然存在。
+package escape
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kind 诊断信息的类别
+type Kind int
+
+const (
+	Other         Kind = iota
+	EscapesToHeap      // x escapes to heap
+	MovedToHeap        // moved to heap: x
+	CanInline          // can inline f
+	InliningCall       // inlining call to f
+	DoesNotEscape      // x does not escape
+	LeakingParam       // leaking param: x
+)
+
+var kindNames = [...]string{
+	Other:         "其他",
+	EscapesToHeap: "逃逸到堆",
+	MovedToHeap:   "分配到堆",
+	CanInline:     "可内联",
+	InliningCall:  "内联调用",
+	DoesNotEscape: "未逃逸",
+	LeakingParam:  "参数泄漏",
+}
+
+func (k Kind) String() string { return kindNames[k] }
+
+func classify(msg string) Kind {
+	switch {
+	case strings.HasPrefix(msg, "moved to heap:"):
+		return MovedToHeap
+	case strings.HasSuffix(msg, "escapes to heap"):
+		return EscapesToHeap
+	case strings.HasPrefix(msg, "can inline "):
+		return CanInline
+	case strings.HasPrefix(msg, "inlining call to "):
+		return InliningCall
+	case strings.HasSuffix(msg, "does not escape"):
+		return DoesNotEscape
+	case strings.HasPrefix(msg, "leaking param"):
+		return LeakingParam
+	}
+	return Other
+}
+
+// Diag 一条编译器诊断
+type Diag struct {
+	File string // 文件名（不含目录），如 "6_function.go"
+	Line int
+	Col  int
+	Kind Kind
+	Msg  string // 编译器原文，如 "moved to heap: count"
+}
+
+// Noise 是否属于信息量很少的诊断：内联展开的调用点和可变参数切片未逃逸，
+// 每个 fmt.Println 都会产生这两条
+func (d Diag) Noise() bool {
+	return d.Kind == InliningCall || d.Msg == "... argument does not escape"
+}
+
+var diagRe = regexp.MustCompile(`^(?:\./)?(.+?\.go):(\d+):(\d+): (.*)$`)
+
+// Parse 解析 -gcflags=-m 的输出，去掉重复项（内联展开会让同一条诊断出现多次），
+// 按文件、行、列排序
+func Parse(output string) []Diag {
+	seen := map[Diag]bool{}
+	var diags []Diag
+	for _, line := range strings.Split(output, "\n") {
+		m := diagRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		ln, _ := strconv.Atoi(m[2])
+		col, _ := strconv.Atoi(m[3])
+		d := Diag{File: filepath.Base(m[1]), Line: ln, Col: col, Kind: classify(m[4]), Msg: m[4]}
+		if !seen[d] {
+			seen[d] = true
+			diags = append(diags, d)
+		}
+	}
+	sort.SliceStable(diags, func(i, j int) bool {
+		a, b := diags[i], diags[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Col < b.Col
+	})
+	return diags
+}
+
+// Build 用 -gcflags=-m 编译目录 dir 中的包，返回解析后的诊断
+func Build(dir string) ([]Diag, error) {
+	cmd := exec.Command("go", "build", "-gcflags=-m", "-o", os.DevNull, ".")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("escape: 编译失败：\n%s", out)
+		}
+		return nil, fmt.Errorf("escape: 无法运行 go build：%w", err)
+	}
+	return Parse(string(out)), nil
+}
+
+// Func 源文件中的一个函数及落在其中的诊断
+type Func struct {
+	Name      string
+	StartLine int
+	EndLine   int
+	Diags     []Diag
+}
+
+// Annotate 把诊断按行号归到 file 中的各个函数（按源码顺序返回）。
+// 闭包的诊断归到外层函数，例如 counter.func1 的诊断归到 counter。
+func Annotate(file string, diags []Diag) ([]Func, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	var funcs []Func
+	for _, decl := range f.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		funcs = append(funcs, Func{
+			Name:      funcName(fd),
+			StartLine: fset.Position(fd.Pos()).Line,
+			EndLine:   fset.Position(fd.End()).Line,
+		})
+	}
+	base := filepath.Base(file)
+	for _, d := range diags {
+		if d.File != base {
+			continue
+		}
+		for i := range funcs {
+			if funcs[i].StartLine <= d.Line && d.Line <= funcs[i].EndLine {
+				funcs[i].Diags = append(funcs[i].Diags, d)
+				break
+			}
+		}
+	}
+	return funcs, nil
+}
+
+// funcName 返回与编译器诊断一致的函数名，方法写作 T.M 或 (*T).M
+func funcName(fd *ast.FuncDecl) string {
+	if fd.Recv == nil || len(fd.Recv.List) == 0 {
+		return fd.Name.Name
+	}
+	switch t := fd.Recv.List[0].Type.(type) {
+	case *ast.StarExpr:
+		if id, ok := t.X.(*ast.Ident); ok {
+			return "(*" + id.Name + ")." + fd.Name.Name
+		}
+	case *ast.Ident:
+		return t.Name + "." + fd.Name.Name
+	}
+	return fd.Name.Name
+}
@@ -0,0 +1,136 @@
+package escape
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// output 是 src 用 go build -gcflags=-m 编译得到的输出
+const src = `package main
+
+import "fmt"
+
+type T struct{ n int }
+
+func (t *T) M() *int { return &t.n }
+func (t T) V() int  { return t.n }
+
+func counter() func() int {
+	count := 0
+	return func() int {
+		count++
+		return count
+	}
+}
+
+func main() {
+	c := counter()
+	t := &T{}
+	fmt.Println(c(), t.M(), T{}.V())
+}
+`
+
+const output = `# esc
+./main.go:7:6: can inline (*T).M
+./main.go:8:6: can inline T.V
+./main.go:10:6: can inline counter
+./main.go:12:9: can inline counter.func1
+./main.go:19:14: inlining call to counter
+./main.go:12:9: can inline counter.func1
+./main.go:21:15: inlining call to counter.func1
+./main.go:21:22: inlining call to (*T).M
+./main.go:21:31: inlining call to T.V
+./main.go:21:13: inlining call to fmt.Println
+<autogenerated>:1: inlining call to T.V
+./main.go:7:7: leaking param: t to result ~r0 level=0
+./main.go:11:2: moved to heap: count
+./main.go:12:9: func literal escapes to heap
+./main.go:19:14: func literal does not escape
+./main.go:20:7: &T{} escapes to heap
+./main.go:21:13: ... argument does not escape
+./main.go:21:15: ~r0 escapes to heap
+./main.go:21:31: ~r0 escapes to heap
+`
+
+func TestClassify(t *testing.T) {
+	for _, tt := range []struct {
+		msg  string
+		want Kind
+	}{
+		{"moved to heap: count", MovedToHeap},
+		{"func literal escapes to heap", EscapesToHeap},
+		{"&T{} escapes to heap", EscapesToHeap},
+		{"func literal does not escape", DoesNotEscape},
+		{"... argument does not escape", DoesNotEscape},
+		{"can inline (*T).M", CanInline},
+		{"can inline counter.func1", CanInline},
+		{"inlining call to fmt.Println", InliningCall},
+		{"leaking param: t to result ~r0 level=0", LeakingParam},
+		{"cannot inline main: function too complex", Other},
+	} {
+		if got := classify(tt.msg); got != tt.want {
+			t.Errorf("classify(%q) = %v，期望 %v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	diags := Parse(output)
+	// 去掉了第一行包名、<autogenerated> 和重复的 counter.func1
+	if len(diags) != 17 {
+		t.Fatalf("Parse 得到 %d 条诊断，期望 17 条：%v", len(diags), diags)
+	}
+	for i := 1; i < len(diags); i++ {
+		a, b := diags[i-1], diags[i]
+		if a.Line > b.Line || a.Line == b.Line && a.Col > b.Col {
+			t.Errorf("第 %d 条诊断没有按行列排序：%v 在 %v 之后", i, b, a)
+		}
+	}
+	want := Diag{File: "main.go", Line: 11, Col: 2, Kind: MovedToHeap, Msg: "moved to heap: count"}
+	if !reflect.DeepEqual(diags[4], want) {
+		t.Errorf("diags[4] = %+v，期望 %+v", diags[4], want)
+	}
+	noise := 0
+	for _, d := range diags {
+		if d.Noise() {
+			noise++
+		}
+	}
+	if noise != 6 {
+		t.Errorf("Noise 的诊断有 %d 条，期望 6 条（5 条内联调用和 1 条可变参数）", noise)
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "main.go")
+	if err := os.WriteFile(file, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	funcs, err := Annotate(file, Parse(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string][]string{}
+	var names []string
+	for _, f := range funcs {
+		names = append(names, f.Name)
+		for _, d := range f.Diags {
+			got[f.Name] = append(got[f.Name], d.Msg)
+		}
+	}
+	// 方法名与编译器一致，写作 (*T).M 和 T.V
+	if want := []string{"(*T).M", "T.V", "counter", "main"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("函数名 %q，期望 %q", names, want)
+	}
+	// 闭包 counter.func1 的诊断归到外层的 counter
+	for name, want := range map[string][]string{
+		"(*T).M":  {"can inline (*T).M", "leaking param: t to result ~r0 level=0"},
+		"counter": {"can inline counter", "moved to heap: count", "can inline counter.func1", "func literal escapes to heap"},
+	} {
+		if !reflect.DeepEqual(got[name], want) {
+			t.Errorf("%s 的诊断 %q，期望 %q", name, got[name], want)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package floatcmp
+
+import (
+	"fmt"
+	"math"
+)
+
+// Describe 描述 got 与 want 的差别：两个值（精确到能区分）、位模式、差值和 ULP 距离，用于测试失败时的信息
+func Describe[T Float](got, want T) string {
+	return fmt.Sprintf("got %s，want %s，相差 %g，ULP 距离 %s",
+		describe(got), describe(want), float64(got)-float64(want), ulpString(ULPDistance(got, want)))
+}
+
+func describe[T Float](x T) string {
+	if is32[T]() {
+		return fmt.Sprintf("%v（0x%08X）", float32(x), math.Float32bits(float32(x)))
+	}
+	return fmt.Sprintf("%v（0x%016X）", float64(x), math.Float64bits(float64(x)))
+}
+
+func ulpString(d uint64) string {
+	if d == math.MaxUint64 {
+		return "无（有 NaN）"
+	}
+	return fmt.Sprint(d)
+}
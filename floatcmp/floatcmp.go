@@ -0,0 +1,139 @@
+// Package floatcmp 比较两个浮点数是否“足够接近”。
+//
+// 3_int_float.go 用 math.Abs(sum-c) < 1e-9 判断相等，但固定的绝对误差只适合 1 附近的数：
+//
+//	1.23e9 附近相邻两个 float64 相差约 2.4e-7，比 1e-9 还大，只要有一点舍入误差就判断为不相等
+//	阈值放宽到 1e-6 后，4.56e-6 与 4.57e-6 这样相差千分之二的数又被判断为相等
+//
+// 本包提供四种比较方式：
+//
+//   - AbsEqual 绝对误差，适合已知量级的数，以及和 0 比较
+//   - RelEqual 相对误差，按两数中较大的绝对值缩放阈值，与量级无关
+//   - ULPEqual ULP 距离，即两数之间还隔着几个可表示的浮点数
+//   - Equal 组合以上三种，任何一种满足即相等；AlmostEqual 使用按类型精度给出的默认容差
+//
+// 所有比较都处理了特殊值：NaN 与任何数（包括自己）都不相等；无穷大只与同号的无穷大相等；
+// +0 与 -0 相等，ULP 距离为 0。
+package floatcmp
+
+import (
+	"math"
+	"unsafe"
+)
+
+// Float 所有底层类型为 float32 或 float64 的类型
+type Float interface {
+	~float32 | ~float64
+}
+
+func is32[T Float]() bool {
+	var x T
+	return unsafe.Sizeof(x) == 4
+}
+
+// Epsilon 机器精度：1 与下一个可表示的数之差，float32 为 2^-23，float64 为 2^-52
+func Epsilon[T Float]() T {
+	if is32[T]() {
+		return T(0x1p-23)
+	}
+	return T(0x1p-52)
+}
+
+// special 处理 NaN 和无穷大，返回比较结果以及是否已经得出结果
+func special(a, b float64) (equal, done bool) {
+	switch {
+	case math.IsNaN(a) || math.IsNaN(b):
+		return false, true
+	case math.IsInf(a, 0) || math.IsInf(b, 0):
+		return a == b, true
+	case a == b: // 包括 +0 == -0
+		return true, true
+	}
+	return false, false
+}
+
+// AbsEqual |a-b| ≤ abs
+func AbsEqual[T Float](a, b, abs T) bool {
+	if eq, done := special(float64(a), float64(b)); done {
+		return eq
+	}
+	return math.Abs(float64(a)-float64(b)) <= float64(abs)
+}
+
+// RelEqual |a-b| ≤ rel × max(|a|, |b|)，如 rel 为 1e-6 表示相差不超过百万分之一。
+// 与 0 比较时任何非 0 的数都不相等，这时应使用 AbsEqual 或 Equal。
+func RelEqual[T Float](a, b, rel T) bool {
+	if eq, done := special(float64(a), float64(b)); done {
+		return eq
+	}
+	// 在 float64 中计算，float32 的差值和乘积都不会再引入误差或溢出
+	diff := math.Abs(float64(a) - float64(b))
+	return diff <= float64(rel)*max(math.Abs(float64(a)), math.Abs(float64(b)))
+}
+
+// ordered 把浮点数的位模式映射为有序整数：相邻的浮点数对应相邻的整数，+0 和 -0 都对应 0
+func ordered[T Float](x T) int64 {
+	var bits, sign uint64
+	if is32[T]() {
+		bits, sign = uint64(math.Float32bits(float32(x))), 1<<31
+	} else {
+		bits, sign = math.Float64bits(float64(x)), 1<<63
+	}
+	if bits&sign != 0 {
+		return -int64(bits &^ sign)
+	}
+	return int64(bits)
+}
+
+// ULPDistance a 与 b 之间相隔几个 ULP（unit in the last place），即从 a 走到 b 要经过几个可表示的数。
+// 相等时为 0，相邻的两个数为 1，+0 与 -0 为 0，跨过 0 时两侧的距离相加。
+// 有 NaN 时返回 math.MaxUint64。
+func ULPDistance[T Float](a, b T) uint64 {
+	if a != a || b != b {
+		return math.MaxUint64
+	}
+	x, y := ordered(a), ordered(b)
+	if x < y {
+		x, y = y, x
+	}
+	return uint64(x) - uint64(y)
+}
+
+// ULPEqual a 与 b 的 ULP 距离不超过 ulps。
+// ULP 距离自动适应量级，但接近 0 时极小的两个数也可能相隔上亿个 ULP，和 0 比较时应使用 AbsEqual 或 Equal。
+func ULPEqual[T Float](a, b T, ulps uint64) bool {
+	if eq, done := special(float64(a), float64(b)); done {
+		return eq
+	}
+	return ULPDistance(a, b) <= ulps
+}
+
+// Tolerance Equal 的容差，为 0 的字段不参与比较
+type Tolerance struct {
+	Abs float64 // 绝对误差，见 AbsEqual
+	Rel float64 // 相对误差，见 RelEqual
+	ULP uint64  // ULP 距离，见 ULPEqual
+}
+
+// DefaultTolerance AlmostEqual 使用的容差：绝对误差为 1 个机器精度，用于和 0 比较；
+// 相对误差为 4 个机器精度，能容忍几步运算累积的舍入误差。
+func DefaultTolerance[T Float]() Tolerance {
+	eps := float64(Epsilon[T]())
+	return Tolerance{Abs: eps, Rel: 4 * eps}
+}
+
+// Equal a 与 b 满足 tol 中任意一种容差即相等
+func Equal[T Float](a, b T, tol Tolerance) bool {
+	if eq, done := special(float64(a), float64(b)); done {
+		return eq
+	}
+	return tol.Abs > 0 && AbsEqual(float64(a), float64(b), tol.Abs) ||
+		tol.Rel > 0 && RelEqual(float64(a), float64(b), tol.Rel) ||
+		tol.ULP > 0 && ULPEqual(a, b, tol.ULP)
+}
+
+// AlmostEqual 按 DefaultTolerance 比较，0.1+0.2 与 0.3 相等，1.23e9 与下一个 float64 相等，
+// 4.56e-6 与 4.57e-6 不相等
+func AlmostEqual[T Float](a, b T) bool {
+	return Equal(a, b, DefaultTolerance[T]())
+}
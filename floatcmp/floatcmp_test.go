@@ -0,0 +1,110 @@
+package floatcmp
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+var (
+	nan    = math.NaN()
+	inf    = math.Inf(1)
+	negZ   = math.Copysign(0, -1)
+	sum    = 0.1 + zero + 0.2 // 加上变量，避免常量运算得到精确的 0.3
+	zero   float64
+	bigNum = 1.23e9
+)
+
+func TestAbsRel(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		got      bool
+		expected bool
+	}{
+		{"0.1+0.2 绝对误差", AbsEqual(sum, 0.3, 1e-9), true},
+		{"1.23e9 相邻数绝对误差", AbsEqual(bigNum, math.Nextafter(bigNum, inf), 1e-9), false},
+		{"1.23e9 相邻数相对误差", RelEqual(bigNum, math.Nextafter(bigNum, inf), 1e-15), true},
+		{"4.56e-6 与 4.57e-6 相对误差", RelEqual(4.56e-6, 4.57e-6, 1e-6), false},
+		{"和 0 比较相对误差", RelEqual(1e-300, 0, 0.5), false},
+		{"NaN 绝对误差", AbsEqual(nan, nan, inf), false},
+		{"NaN 相对误差", RelEqual(nan, 1, inf), false},
+		{"同号无穷大", AbsEqual(inf, inf, 0), true},
+		{"异号无穷大", RelEqual(inf, -inf, inf), false},
+		{"无穷大与最大值", RelEqual(inf, math.MaxFloat64, 1), false},
+		{"正负 0", AbsEqual(0, negZ, 0), true},
+		{"float32", RelEqual(float32(0.1)+float32(0.2), float32(0.3), 1e-6), true},
+	} {
+		if tt.got != tt.expected {
+			t.Errorf("%s：结果为 %t", tt.name, tt.got)
+		}
+	}
+}
+
+func TestULP(t *testing.T) {
+	for _, tt := range []struct {
+		a, b float64
+		want uint64
+	}{
+		{1, 1, 0},
+		{1, math.Nextafter(1, 2), 1},
+		{sum, 0.3, 1},
+		{0, negZ, 0},
+		{math.SmallestNonzeroFloat64, -math.SmallestNonzeroFloat64, 2},
+		{math.MaxFloat64, inf, 1},
+		{-1, 1, 2 * 0x3FF0000000000000},
+		{nan, 1, math.MaxUint64},
+	} {
+		if got := ULPDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("ULPDistance(%v, %v) = %d，期望 %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := ULPDistance(tt.b, tt.a); got != tt.want {
+			t.Errorf("ULPDistance(%v, %v) = %d，期望 %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+	if got := ULPDistance(float32(1), math.Nextafter32(1, 2)); got != 1 {
+		t.Errorf("float32 相邻数的 ULP 距离 = %d", got)
+	}
+	if got := ULPDistance(-inf, inf); got != 2*0x7FF0000000000000 {
+		t.Errorf("正负无穷大的 ULP 距离 = %d", got)
+	}
+	if !ULPEqual(sum, 0.3, 1) || ULPEqual(sum, 0.3, 0) || ULPEqual(nan, nan, math.MaxUint64) || ULPEqual(math.MaxFloat64, inf, 1) {
+		t.Error("ULPEqual 结果不正确")
+	}
+}
+
+type celsius float32
+
+func TestAlmostEqual(t *testing.T) {
+	if !AlmostEqual(sum, 0.3) || !AlmostEqual(bigNum, math.Nextafter(bigNum, 0)) || AlmostEqual(4.56e-6, 4.57e-6) {
+		t.Error("AlmostEqual 对课程中的例子判断错误")
+	}
+	if !AlmostEqual(1-0.9-0.1+zero, 0) {
+		t.Error("接近 0 的误差应当忽略")
+	}
+	if AlmostEqual(nan, nan) || !AlmostEqual(negZ, 0) || AlmostEqual(inf, -inf) || !AlmostEqual(-inf, -inf) {
+		t.Error("AlmostEqual 对特殊值判断错误")
+	}
+	var c celsius = 36.6
+	if !AlmostEqual(c*3/3, 36.6) || AlmostEqual(c, 36.61) {
+		t.Error("AlmostEqual 对 float32 的命名类型判断错误")
+	}
+	if Epsilon[celsius]() != 0x1p-23 || Epsilon[float64]() != 0x1p-52 {
+		t.Error("Epsilon 不正确")
+	}
+	tol := Tolerance{ULP: 2}
+	if Equal(1e-300, 0, tol) || !Equal(1e-300, 0, Tolerance{Abs: 1e-200}) || Equal(1.0, 2, Tolerance{}) {
+		t.Error("Equal 没有按指定容差比较")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	if got := Describe(sum, 0.31); got != "got 0.30000000000000004（0x3FD3333333333334），want 0.31（0x3FD3D70A3D70A3D7），相差 -0.009999999999999953，ULP 距离 180143985094819" {
+		t.Errorf("Describe(float64) = %q", got)
+	}
+	if got := Describe(nan, 1); !strings.Contains(got, "ULP 距离 无（有 NaN）") {
+		t.Errorf("Describe(NaN) = %q", got)
+	}
+	if got := Describe(float32(0.1), 0.1); !strings.Contains(got, "0.1（0x3DCCCCCD）") || !strings.Contains(got, "ULP 距离 0") {
+		t.Errorf("Describe(float32) = %q", got)
+	}
+}
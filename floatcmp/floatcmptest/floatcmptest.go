@@ -0,0 +1,31 @@
+// Package floatcmptest 提供测试中比较浮点数的辅助函数。
+//
+// 它与 floatcmp 分开，floatcmp 本身不依赖 testing 包，课程代码和命令行工具导入 floatcmp 时不会把 testing 一起编译进去。
+package floatcmptest
+
+import (
+	"testing"
+
+	"github.com/colayear/go_learning/floatcmp"
+)
+
+// AssertAlmostEqual 在测试中检查 floatcmp.AlmostEqual(got, want)，不相等时用 tb.Errorf 报告 floatcmp.Describe 的内容。
+// 返回是否相等，便于调用方在不相等时跳过后续检查。
+func AssertAlmostEqual[T floatcmp.Float](tb testing.TB, got, want T) bool {
+	tb.Helper()
+	if floatcmp.AlmostEqual(got, want) {
+		return true
+	}
+	tb.Errorf("浮点数不相等：%s", floatcmp.Describe(got, want))
+	return false
+}
+
+// AssertEqual 与 AssertAlmostEqual 相同，但使用指定的容差
+func AssertEqual[T floatcmp.Float](tb testing.TB, got, want T, tol floatcmp.Tolerance) bool {
+	tb.Helper()
+	if floatcmp.Equal(got, want, tol) {
+		return true
+	}
+	tb.Errorf("浮点数不相等：%s，容差 %+v", floatcmp.Describe(got, want), tol)
+	return false
+}
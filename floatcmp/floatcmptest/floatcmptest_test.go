@@ -0,0 +1,45 @@
+package floatcmptest
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/colayear/go_learning/floatcmp"
+)
+
+// recorder 记录 Errorf 的内容，其他方法沿用内嵌的 testing.TB
+type recorder struct {
+	testing.TB
+	msgs []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.msgs = append(r.msgs, fmt.Sprintf(format, args...))
+}
+
+var (
+	zero float64
+	sum  = 0.1 + zero + 0.2 // 加上变量，避免常量运算得到精确的 0.3
+)
+
+func TestAssert(t *testing.T) {
+	r := &recorder{TB: t}
+	if !AssertAlmostEqual(r, sum, 0.3) || !AssertEqual(r, float32(1), float32(1.0000001), floatcmp.Tolerance{ULP: 1}) || len(r.msgs) != 0 {
+		t.Fatalf("相等时不应报错：%v", r.msgs)
+	}
+	if AssertAlmostEqual(r, sum, 0.31) || AssertEqual(r, math.NaN(), 1, floatcmp.Tolerance{Abs: 1}) {
+		t.Fatal("不相等时应当返回 false")
+	}
+	for i, want := range []string{
+		"浮点数不相等：got 0.30000000000000004（0x3FD3333333333334），want 0.31（0x3FD3D70A3D70A3D7）",
+		"ULP 距离 无（有 NaN），容差 {Abs:1 Rel:0 ULP:0}",
+	} {
+		if i >= len(r.msgs) || !strings.Contains(r.msgs[i], want) {
+			t.Errorf("第 %d 条报错中没有 %q：%v", i+1, want, r.msgs)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/colayear/go_learning
+
+go 1.23
@@ -0,0 +1,182 @@
+//go:build ignore
+
+// gen 从 Unicode 字符数据库（UCD）生成字素簇断行属性表 tables.go。
+//
+//	go generate ./grapheme
+//	go run gen.go -ucd /path/to/ucd        # 使用本地下载好的 UCD 目录
+//
+// 需要 UCD 中的两个文件：
+//
+//	auxiliary/GraphemeBreakProperty.txt   Grapheme_Cluster_Break 属性
+//	emoji/emoji-data.txt                  Extended_Pictographic 属性（规则 GB11）
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	version = flag.String("version", "14.0.0", "Unicode 版本")
+	ucd     = flag.String("ucd", "", "UCD 的 URL 或本地目录，默认 https://www.unicode.org/Public/<版本>/ucd")
+	output  = flag.String("o", "tables.go", "输出文件")
+)
+
+// 属性名与 grapheme.go 中 property 常量的对应关系
+var propNames = map[string]string{
+	"CR":                    "prCR",
+	"LF":                    "prLF",
+	"Control":               "prControl",
+	"Extend":                "prExtend",
+	"ZWJ":                   "prZWJ",
+	"Regional_Indicator":    "prRegionalIndicator",
+	"Prepend":               "prPrepend",
+	"SpacingMark":           "prSpacingMark",
+	"L":                     "prL",
+	"V":                     "prV",
+	"T":                     "prT",
+	"LV":                    "prLV",
+	"LVT":                   "prLVT",
+	"Extended_Pictographic": "prExtendedPictographic",
+}
+
+type propRange struct {
+	lo, hi rune
+	prop   string
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gen: ")
+	flag.Parse()
+	base := *ucd
+	if base == "" {
+		base = "https://www.unicode.org/Public/" + *version + "/ucd"
+	}
+
+	props, err := parse(base, "auxiliary/GraphemeBreakProperty.txt", nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	pict, err := parse(base, "emoji/emoji-data.txt", map[string]bool{"Extended_Pictographic": true})
+	if err != nil {
+		log.Fatal(err)
+	}
+	ranges, err := merge(props, pict)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gen.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package grapheme\n\n")
+	fmt.Fprintf(&buf, "// UnicodeVersion 生成断行属性表所用的 Unicode 版本\n")
+	fmt.Fprintf(&buf, "const UnicodeVersion = %q\n\n", *version)
+	fmt.Fprintf(&buf, "// properties 按码点排序、互不重叠的区间，未列出的码点属性为 prOther。\n")
+	fmt.Fprintf(&buf, "// 来源：GraphemeBreakProperty.txt 与 emoji-data.txt 中的 Extended_Pictographic。\n")
+	fmt.Fprintf(&buf, "var properties = [...]propRange{\n")
+	for _, r := range ranges {
+		fmt.Fprintf(&buf, "\t{0x%04X, 0x%04X, %s},\n", r.lo, r.hi, propNames[r.prop])
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("已生成 %s：%d 个区间（Unicode %s）", *output, len(ranges), *version)
+}
+
+// parse 解析 UCD 属性文件，行格式为 "0000..001F ; Control # 注释"。
+// only 不为 nil 时只保留其中列出的属性。
+func parse(base, name string, only map[string]bool) ([]propRange, error) {
+	rc, err := open(base, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var ranges []propRange
+	sc := bufio.NewScanner(rc)
+	for n := 1; sc.Scan(); n++ {
+		line, _, _ := strings.Cut(sc.Text(), "#")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		cps, prop, ok := strings.Cut(line, ";")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d：格式错误：%q", name, n, sc.Text())
+		}
+		prop = strings.TrimSpace(prop)
+		if only != nil && !only[prop] {
+			continue
+		}
+		if _, known := propNames[prop]; !known {
+			return nil, fmt.Errorf("%s:%d：未知属性 %q", name, n, prop)
+		}
+		loStr, hiStr, isRange := strings.Cut(strings.TrimSpace(cps), "..")
+		if !isRange {
+			hiStr = loStr
+		}
+		lo, err1 := strconv.ParseUint(loStr, 16, 32)
+		hi, err2 := strconv.ParseUint(hiStr, 16, 32)
+		if err1 != nil || err2 != nil || lo > hi {
+			return nil, fmt.Errorf("%s:%d：无效码点区间 %q", name, n, cps)
+		}
+		ranges = append(ranges, propRange{rune(lo), rune(hi), prop})
+	}
+	return ranges, sc.Err()
+}
+
+func open(base, name string) (io.ReadCloser, error) {
+	if !strings.HasPrefix(base, "http://") && !strings.HasPrefix(base, "https://") {
+		return os.Open(filepath.Join(base, filepath.FromSlash(name)))
+	}
+	url := strings.TrimSuffix(base, "/") + "/" + name
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("下载 %s 失败：%s", url, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// merge 合并两组区间并排序，相邻且属性相同的区间合并为一个。
+// Extended_Pictographic 的码点在 Grapheme_Cluster_Break 中都是 Other，两者重叠说明数据有误。
+func merge(props, pict []propRange) ([]propRange, error) {
+	all := append(props, pict...)
+	sort.Slice(all, func(i, j int) bool { return all[i].lo < all[j].lo })
+	var out []propRange
+	for _, r := range all {
+		if n := len(out); n > 0 {
+			last := &out[n-1]
+			if r.lo <= last.hi {
+				return nil, fmt.Errorf("区间重叠：%04X..%04X %s 与 %04X..%04X %s",
+					last.lo, last.hi, last.prop, r.lo, r.hi, r.prop)
+			}
+			if r.lo == last.hi+1 && r.prop == last.prop {
+				last.hi = r.hi
+				continue
+			}
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
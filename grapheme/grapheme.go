@@ -0,0 +1,211 @@
+// Package grapheme 按字素簇（grapheme cluster，用户眼中的“一个字”）切分字符串，
+// 实现 Unicode 标准附件 UAX #29 的扩展字素簇断行规则。
+//
+// 2_string.go 中用 for range 按 rune 统计字符数，对中文没有问题，但一个“字”可能由多个 rune 组成：
+//
+//	"é"     e + U+0301（组合重音）          2 个 rune，1 个字素簇
+//	"👍🏽"    竖大拇指 + 肤色修饰符            2 个 rune，1 个字素簇
+//	"🇨🇳"    两个区域指示符组成的国旗        2 个 rune，1 个字素簇
+//	"👨‍👩‍👧"   三个 emoji 用零宽连接符（ZWJ）连接  5 个 rune，1 个字素簇
+//
+// 断行属性表 tables.go 由 gen.go 根据 Unicode 字符数据库生成。
+package grapheme
+
+//go:generate go run gen.go -version 14.0.0
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+	"unicode/utf8"
+)
+
+// property Grapheme_Cluster_Break 属性，另加 Extended_Pictographic
+type property uint8
+
+const (
+	prOther property = iota
+	prCR
+	prLF
+	prControl
+	prExtend
+	prZWJ
+	prRegionalIndicator
+	prPrepend
+	prSpacingMark
+	prL   // 谚文初声
+	prV   // 谚文中声
+	prT   // 谚文终声
+	prLV  // 谚文音节（初声+中声）
+	prLVT // 谚文音节（初声+中声+终声）
+	prExtendedPictographic
+)
+
+type propRange struct {
+	lo, hi rune
+	prop   property
+}
+
+func lookup(r rune) property {
+	if r < 0x7F { // ASCII 中只有控制字符有特殊属性
+		switch {
+		case r == '\r':
+			return prCR
+		case r == '\n':
+			return prLF
+		case r < 0x20:
+			return prControl
+		}
+		return prOther
+	}
+	lo, hi := 0, len(properties)
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		switch {
+		case r < properties[m].lo:
+			hi = m
+		case r > properties[m].hi:
+			lo = m + 1
+		default:
+			return properties[m].prop
+		}
+	}
+	return prOther
+}
+
+// firstLen 返回 s 中第一个字素簇的字节长度
+func firstLen(s string) int {
+	if s == "" {
+		return 0
+	}
+	r, i := utf8.DecodeRuneInString(s)
+	prev := lookup(r)
+	// GB11 的状态：当前位于 ExtPict Extend* 之后，以及 ExtPict Extend* ZWJ 之后
+	inPict := prev == prExtendedPictographic
+	pictZWJ := false
+	// GB12、GB13 的状态：以 prev 结尾的连续区域指示符个数
+	ri := 0
+	if prev == prRegionalIndicator {
+		ri = 1
+	}
+
+	for i < len(s) {
+		r, n := utf8.DecodeRuneInString(s[i:])
+		cur := lookup(r)
+		if boundary(prev, cur, pictZWJ, ri) {
+			break
+		}
+		pictZWJ = cur == prZWJ && inPict
+		switch cur {
+		case prExtendedPictographic:
+			inPict = true
+		case prExtend:
+			// ExtPict 之后的 Extend 不改变状态
+		default:
+			inPict = false
+		}
+		if cur == prRegionalIndicator {
+			ri++
+		} else {
+			ri = 0
+		}
+		prev = cur
+		i += n
+	}
+	return i
+}
+
+// boundary 按 UAX #29 的规则 GB3 ~ GB999 判断 prev 与 cur 之间是否断开
+func boundary(prev, cur property, pictZWJ bool, ri int) bool {
+	switch {
+	case prev == prCR && cur == prLF: // GB3
+		return false
+	case prev == prControl || prev == prCR || prev == prLF: // GB4
+		return true
+	case cur == prControl || cur == prCR || cur == prLF: // GB5
+		return true
+	case prev == prL && (cur == prL || cur == prV || cur == prLV || cur == prLVT): // GB6
+		return false
+	case (prev == prLV || prev == prV) && (cur == prV || cur == prT): // GB7
+		return false
+	case (prev == prLVT || prev == prT) && cur == prT: // GB8
+		return false
+	case cur == prExtend || cur == prZWJ: // GB9
+		return false
+	case cur == prSpacingMark: // GB9a
+		return false
+	case prev == prPrepend: // GB9b
+		return false
+	case pictZWJ && cur == prExtendedPictographic: // GB11
+		return false
+	case prev == prRegionalIndicator && cur == prRegionalIndicator: // GB12、GB13
+		return ri%2 == 0
+	}
+	return true // GB999
+}
+
+// Graphemes 依次产生 s 中的每个字素簇（原字符串的子串）
+func Graphemes(s string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for s != "" {
+			n := firstLen(s)
+			if !yield(s[:n]) {
+				return
+			}
+			s = s[n:]
+		}
+	}
+}
+
+// Count 字素簇个数，即用户看到的“字数”
+func Count(s string) int {
+	n := 0
+	for s != "" {
+		s = s[firstLen(s):]
+		n++
+	}
+	return n
+}
+
+// ErrRange 字素簇下标越界
+var ErrRange = errors.New("grapheme: 字素簇下标越界")
+
+// Substring 第 start 到 end-1 个字素簇组成的子串，不会把 emoji、国旗或组合字符切开。
+// 下标越界或 start > end 时返回 ErrRange。
+func Substring(s string, start, end int) (string, error) {
+	if start < 0 || start > end {
+		return "", rangeError(s, start, end)
+	}
+	i, n := 0, 0
+	for ; n < start && i < len(s); n++ {
+		i += firstLen(s[i:])
+	}
+	j := i
+	for ; n < end && j < len(s); n++ {
+		j += firstLen(s[j:])
+	}
+	if n < end {
+		return "", rangeError(s, start, end)
+	}
+	return s[i:j], nil
+}
+
+// Reverse 按字素簇反转字符串。
+// 按 rune 反转会把组合重音挪到前一个字母上、把国旗拆成别的国家，按字素簇反转则不会。
+func Reverse(s string) string {
+	var clusters []string
+	for g := range Graphemes(s) {
+		clusters = append(clusters, g)
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := len(clusters) - 1; i >= 0; i-- {
+		b.WriteString(clusters[i])
+	}
+	return b.String()
+}
+
+func rangeError(s string, start, end int) error {
+	return fmt.Errorf("%w：[%d:%d]，字素簇个数为 %d", ErrRange, start, end, Count(s))
+}
@@ -0,0 +1,124 @@
+package grapheme
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []string
+	}{
+		{"", nil},
+		{"Go语言", []string{"G", "o", "语", "言"}},
+		// GB9 组合重音
+		{"e\u0301a", []string{"e\u0301", "a"}},
+		// GB3、GB4
+		{"\r\n\n", []string{"\r\n", "\n"}},
+		// GB9 肤色修饰符
+		{"\U0001F44D\U0001F3FD!", []string{"\U0001F44D\U0001F3FD", "!"}},
+		// GB12、GB13 国旗
+		{"\U0001F1E8\U0001F1F3\U0001F1EF\U0001F1F5\U0001F1FA", []string{"\U0001F1E8\U0001F1F3", "\U0001F1EF\U0001F1F5", "\U0001F1FA"}},
+		// GB11 ZWJ 序列
+		{"\U0001F468\u200D\U0001F469\u200D\U0001F467", []string{"\U0001F468\u200D\U0001F469\u200D\U0001F467"}},
+		// GB11 Extend 后跟 ZWJ
+		{"\u2764\uFE0F\u200D\U0001F525", []string{"\u2764\uFE0F\u200D\U0001F525"}},
+		// 非 emoji 后的 ZWJ 不连接
+		{"a\u200D\U0001F469", []string{"a\u200D", "\U0001F469"}},
+		// GB6、GB7 谚文字母组成音节
+		{"\u1100\u1161\u11A8", []string{"\u1100\u1161\u11A8"}},
+		// GB9a 天城文元音符号
+		{"\u0915\u093F", []string{"\u0915\u093F"}},
+		// GB9b 阿拉伯文前置符
+		{"\u0600\u0661", []string{"\u0600\u0661"}},
+	}
+	for _, tt := range tests {
+		got := slices.Collect(Graphemes(tt.s))
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Graphemes(%q) = %q，期望 %q", tt.s, got, tt.want)
+		}
+		if n := Count(tt.s); n != len(tt.want) {
+			t.Errorf("Count(%q) = %d，期望 %d", tt.s, n, len(tt.want))
+		}
+	}
+}
+
+func TestSubstringReverse(t *testing.T) {
+	const s = "Go🇨🇳é👍🏽"
+	if got, err := Substring(s, 2, 4); got != "🇨🇳é" || err != nil {
+		t.Errorf("Substring(%q, 2, 4) = %q, %v", s, got, err)
+	}
+	if got, err := Substring(s, 5, 5); got != "" || err != nil {
+		t.Errorf("Substring(%q, 5, 5) = %q, %v", s, got, err)
+	}
+	for _, r := range [][2]int{{0, 6}, {-1, 1}, {3, 2}} {
+		if _, err := Substring(s, r[0], r[1]); !errors.Is(err, ErrRange) {
+			t.Errorf("Substring(%q, %d, %d) 应返回 ErrRange，实际为 %v", s, r[0], r[1], err)
+		}
+	}
+	if got, want := Reverse(s), "👍🏽é🇨🇳oG"; got != want {
+		t.Errorf("Reverse(%q) = %q，期望 %q", s, got, want)
+	}
+}
+
+// TestUnicodeBreakTest 用 Unicode 官方的 GraphemeBreakTest.txt 检查断行规则。
+// 每行是一组码点，÷ 表示断开、× 表示不断开，# 之后是注释。
+// 数据来自 https://www.unicode.org/Public/14.0.0/ucd/auxiliary/GraphemeBreakTest.txt，
+// 用 gen.go 升级 Unicode 版本时需要一并替换。
+func TestUnicodeBreakTest(t *testing.T) {
+	f, err := os.Open("testdata/GraphemeBreakTest.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	if !sc.Scan() || !strings.Contains(sc.Text(), "-"+UnicodeVersion+".txt") {
+		t.Fatalf("测试数据的版本与属性表（Unicode %s）不一致：%q", UnicodeVersion, sc.Text())
+	}
+	lines := 0
+	for n := 2; sc.Scan(); n++ {
+		line, _, _ := strings.Cut(sc.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		var (
+			s    strings.Builder
+			want []string
+			cur  strings.Builder
+		)
+		for _, tok := range fields {
+			switch tok {
+			case "÷":
+				if cur.Len() > 0 {
+					want = append(want, cur.String())
+					cur.Reset()
+				}
+			case "×":
+			default:
+				r, err := strconv.ParseUint(tok, 16, 32)
+				if err != nil {
+					t.Fatalf("第 %d 行：无效的码点 %q", n, tok)
+				}
+				s.WriteRune(rune(r))
+				cur.WriteRune(rune(r))
+			}
+		}
+		lines++
+		if got := slices.Collect(Graphemes(s.String())); !slices.Equal(got, want) {
+			t.Errorf("第 %d 行 %s：Graphemes = %+q，期望 %+q", n, strings.TrimSpace(line), got, want)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if lines == 0 {
+		t.Fatal("测试数据中没有用例")
+	}
+}
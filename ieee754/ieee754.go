@@ -0,0 +1,187 @@
+// Package ieee754 展示浮点数在内存中的样子：符号位、指数、尾数，实际存储的精确十进制值，
+// 相邻的可表示数，以及一次运算产生的舍入误差。
+//
+// 3_int_float.go 说 0.1 + 0.2 ≠ 0.3 是“二进制存储导致的精度丢失”，本包把这句话展开：
+//
+//	0.1 实际存储为 0.1000000000000000055511151231257827021181583404541015625
+//	0.2 实际存储为 0.200000000000000011102230246251565404236316680908203125
+//	两者精确相加为 0.3000000000000000166533453693773481063544750213623046875，
+//	不能用 float64 表示，舍入到最近的 0.3000000000000000444089209850062616169452667236328125，
+//	而 0.3 存储为 0.299999999999999988897769753748434595763683319091796875，两者相差 1 个 ULP。
+//
+// 命令行用法见 golearn float。
+package ieee754
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Class 浮点数的类别
+type Class int
+
+const (
+	Zero      Class = iota // ±0：指数和尾数全为 0
+	Subnormal              // 非规格化数：指数全为 0，没有隐含的 1，用于表示极小的数
+	Normal                 // 规格化数：值为 1.尾数 × 2^(指数-偏移量)
+	Inf                    // ±无穷大：指数全为 1，尾数为 0
+	NaN                    // 非数：指数全为 1，尾数不为 0
+)
+
+var classNames = [...]string{
+	Zero:      "零",
+	Subnormal: "非规格化数",
+	Normal:    "规格化数",
+	Inf:       "无穷大",
+	NaN:       "NaN",
+}
+
+func (c Class) String() string { return classNames[c] }
+
+// Parts 浮点数按 IEEE 754 拆开的各个字段
+type Parts struct {
+	BitSize  int    // 32（float32）或 64（float64）
+	Bits     uint64 // 完整的位模式
+	Sign     uint64 // 符号位：0 为正，1 为负
+	Exponent uint64 // 指数字段，含偏移量
+	Mantissa uint64 // 尾数字段，不含隐含的 1
+}
+
+// Inspect 拆开 f。bitSize 为 32 时先把 f 舍入为 float32，与 strconv.ParseFloat 的 bitSize 含义相同。
+func Inspect(f float64, bitSize int) Parts {
+	if bitSize == 32 {
+		return fromBits(uint64(math.Float32bits(float32(f))), 32)
+	}
+	return fromBits(math.Float64bits(f), 64)
+}
+
+func fromBits(bits uint64, bitSize int) Parts {
+	p := Parts{BitSize: bitSize, Bits: bits}
+	p.Sign = bits >> (bitSize - 1)
+	p.Exponent = bits >> p.MantBits() & (1<<p.ExpBits() - 1)
+	p.Mantissa = bits & (1<<p.MantBits() - 1)
+	return p
+}
+
+// ExpBits 指数字段的位数：float32 为 8，float64 为 11
+func (p Parts) ExpBits() int {
+	if p.BitSize == 32 {
+		return 8
+	}
+	return 11
+}
+
+// MantBits 尾数字段的位数：float32 为 23，float64 为 52
+func (p Parts) MantBits() int { return p.BitSize - 1 - p.ExpBits() }
+
+// Bias 指数的偏移量：float32 为 127，float64 为 1023
+func (p Parts) Bias() int { return 1<<(p.ExpBits()-1) - 1 }
+
+// Class 类别
+func (p Parts) Class() Class {
+	switch maxExp := uint64(1)<<p.ExpBits() - 1; {
+	case p.Exponent == maxExp && p.Mantissa == 0:
+		return Inf
+	case p.Exponent == maxExp:
+		return NaN
+	case p.Exponent == 0 && p.Mantissa == 0:
+		return Zero
+	case p.Exponent == 0:
+		return Subnormal
+	}
+	return Normal
+}
+
+// Exp 去掉偏移量后的实际指数。非规格化数的指数固定为 1-偏移量，零、无穷大和 NaN 返回 0。
+func (p Parts) Exp() int {
+	switch p.Class() {
+	case Normal:
+		return int(p.Exponent) - p.Bias()
+	case Subnormal:
+		return 1 - p.Bias()
+	}
+	return 0
+}
+
+// Float 对应的浮点数；BitSize 为 32 时转换为 float64 没有误差
+func (p Parts) Float() float64 {
+	if p.BitSize == 32 {
+		return float64(math.Float32frombits(uint32(p.Bits)))
+	}
+	return math.Float64frombits(p.Bits)
+}
+
+// BitString 以空格分隔的符号位、指数、尾数，如 0.1 为 "0 01111111011 1001100110011…1010"
+func (p Parts) BitString() string {
+	return fmt.Sprintf("%b %0*b %0*b", p.Sign, p.ExpBits(), p.Exponent, p.MantBits(), p.Mantissa)
+}
+
+// Formula 值的计算公式，如 0.1 为 "+1.1001100110011…1010₂ × 2^-4"；尾数末尾的 0 省略
+func (p Parts) Formula() string {
+	sign := "+"
+	if p.Sign == 1 {
+		sign = "-"
+	}
+	lead := "1."
+	switch p.Class() {
+	case Zero, Inf, NaN:
+		return sign + p.Class().String()
+	case Subnormal:
+		lead = "0."
+	}
+	frac := strings.TrimRight(fmt.Sprintf("%0*b", p.MantBits(), p.Mantissa), "0")
+	if frac == "" {
+		frac = "0"
+	}
+	return fmt.Sprintf("%s%s%s₂ × 2^%d", sign, lead, frac, p.Exp())
+}
+
+// Next 沿正无穷方向紧挨着的可表示数；Prev 沿负无穷方向
+func (p Parts) Next() Parts { return p.toward(math.Inf(1)) }
+
+// Prev 沿负无穷方向紧挨着的可表示数
+func (p Parts) Prev() Parts { return p.toward(math.Inf(-1)) }
+
+func (p Parts) toward(dir float64) Parts {
+	if p.BitSize == 32 {
+		return Inspect(float64(math.Nextafter32(float32(p.Float()), float32(dir))), 32)
+	}
+	return Inspect(math.Nextafter(p.Float(), dir), 64)
+}
+
+// Exact 实际存储的精确十进制值，如 Exact(0.1) 为 "0.1000000000000000055511151231257827021181583404541015625"。
+// 有限的浮点数都是 m × 2^e，一定能写成有限位的十进制小数。NaN 和无穷大返回 "NaN"、"+Inf"、"-Inf"。
+func Exact(f float64) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Sprint(f)
+	}
+	s := RatString(new(big.Rat).SetFloat64(f))
+	if f == 0 && math.Signbit(f) {
+		s = "-" + s
+	}
+	return s
+}
+
+// RatString 有理数的十进制表示。分母只含因子 2 和 5 时是精确的有限小数；
+// 否则保留 40 位小数并以 "…" 结尾，如 1/3 为 "0.3333333333333333333333333333333333333333…"
+func RatString(r *big.Rat) string {
+	d := new(big.Int).Set(r.Denom())
+	twos := d.TrailingZeroBits()
+	d.Rsh(d, twos)
+	fives := uint(0)
+	five, m := big.NewInt(5), new(big.Int)
+	for d.Cmp(five) >= 0 {
+		q, _ := new(big.Int).QuoRem(d, five, m)
+		if m.Sign() != 0 {
+			break
+		}
+		d = q
+		fives++
+	}
+	if d.IsInt64() && d.Int64() == 1 {
+		return r.FloatString(int(max(twos, fives)))
+	}
+	return r.FloatString(40) + "…"
+}
@@ -0,0 +1,148 @@
+package ieee754
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestInspect(t *testing.T) {
+	p := Inspect(0.1, 64)
+	if p.Sign != 0 || p.Exponent != 1019 || p.Mantissa != 0x999999999999A || p.Exp() != -4 || p.Class() != Normal {
+		t.Errorf("Inspect(0.1) = %+v", p)
+	}
+	if got := p.BitString(); got != "0 01111111011 1001100110011001100110011001100110011001100110011010" {
+		t.Errorf("BitString = %q", got)
+	}
+	if got := p.Formula(); got != "+1.100110011001100110011001100110011001100110011001101₂ × 2^-4" {
+		t.Errorf("Formula = %q", got)
+	}
+
+	p = Inspect(0.1, 32)
+	if p.Bits != 0x3DCCCCCD || p.Exponent != 123 || p.Mantissa != 0x4CCCCD || p.Bias() != 127 || p.Float() != float64(float32(0.1)) {
+		t.Errorf("Inspect(0.1, 32) = %+v", p)
+	}
+	if got := Inspect(-2, 32).BitString(); got != "1 10000000 00000000000000000000000" {
+		t.Errorf("-2 的 BitString = %q", got)
+	}
+
+	for _, tt := range []struct {
+		f       float64
+		bitSize int
+		class   Class
+		formula string
+	}{
+		{0, 64, Zero, "+零"},
+		{math.Copysign(0, -1), 64, Zero, "-零"},
+		{math.SmallestNonzeroFloat64, 64, Subnormal, "+0.0000000000000000000000000000000000000000000000000001₂ × 2^-1022"},
+		{1, 32, Normal, "+1.0₂ × 2^0"},
+		{math.Inf(-1), 32, Inf, "-无穷大"},
+		{math.NaN(), 64, NaN, "+NaN"},
+	} {
+		p := Inspect(tt.f, tt.bitSize)
+		if p.Class() != tt.class || p.Formula() != tt.formula {
+			t.Errorf("Inspect(%v, %d)：%s，%q", tt.f, tt.bitSize, p.Class(), p.Formula())
+		}
+	}
+
+	if got := Inspect(1, 64).Next().Float(); got != 1+0x1p-52 {
+		t.Errorf("1 的后一个 float64 = %v", got)
+	}
+	if got := Inspect(1, 32).Prev().Float(); got != 1-0x1p-24 {
+		t.Errorf("1 的前一个 float32 = %v", got)
+	}
+}
+
+func TestExact(t *testing.T) {
+	for _, tt := range []struct {
+		f    float64
+		want string
+	}{
+		{0.1, "0.1000000000000000055511151231257827021181583404541015625"},
+		{float64(float32(0.1)), "0.100000001490116119384765625"},
+		{0.5, "0.5"},
+		{-3, "-3"},
+		{1e22, "10000000000000000000000"},
+		{1e23, "99999999999999991611392"},
+		{math.Copysign(0, -1), "-0"},
+		{math.Inf(1), "+Inf"},
+	} {
+		if got := Exact(tt.f); got != tt.want {
+			t.Errorf("Exact(%v) = %s，期望 %s", tt.f, got, tt.want)
+		}
+	}
+	if got := Exact(math.SmallestNonzeroFloat64); len(got) != 2+323+751 {
+		t.Errorf("最小非规格化数的精确值有 %d 个字符", len(got))
+	}
+}
+
+func TestParseOp(t *testing.T) {
+	o, err := ParseOp(" 0.1 + 0.2 ", 64)
+	if err != nil || o.XText != "0.1" || o.YText != "0.2" || o.Result != 0.30000000000000004 {
+		t.Fatalf("ParseOp = %+v, %v", o, err)
+	}
+	for _, tt := range []struct {
+		f    func() string
+		want string
+	}{
+		{func() string { return RatString(o.Exact()) }, "0.3000000000000000166533453693773481063544750213623046875"},
+		{func() string { return RatString(o.RoundingError()) }, "0.0000000000000000277555756156289135105907917022705078125"},
+		{func() string { return RatString(o.Intended()) }, "0.3"},
+		{func() string { return RatString(o.TotalError()) }, "0.0000000000000000444089209850062616169452667236328125"},
+	} {
+		if got := tt.f(); got != tt.want {
+			t.Errorf("得到 %s，期望 %s", got, tt.want)
+		}
+	}
+
+	o, _ = ParseOp("0.1+0.2", 32)
+	if float32(o.Result) != float32(0.3) || o.RoundingError().Sign() <= 0 {
+		t.Errorf("float32 的 0.1+0.2 = %v", o.Result)
+	}
+	o, _ = ParseOp("1/3", 64)
+	if got := RatString(o.Intended()); got != "0.3333333333333333333333333333333333333333…" {
+		t.Errorf("1/3 = %s", got)
+	}
+
+	for _, tt := range []struct {
+		expr string
+		x, y float64
+		op   byte
+	}{
+		{"-1.5e-3*2", -1.5e-3, 2, '*'},
+		{"1e+5 - -2", 1e5, -2, '-'},
+		{"0x1p-3+1", 0.125, 1, '+'},
+		{"2E-1/4", 0.2, 4, '/'},
+	} {
+		o, err := ParseOp(tt.expr, 64)
+		if err != nil || o.X != tt.x || o.Y != tt.y || o.Operator != tt.op {
+			t.Errorf("ParseOp(%q) = %+v, %v", tt.expr, o, err)
+		}
+	}
+	if o, _ := ParseOp("1/0", 64); !math.IsInf(o.Result, 1) || o.Exact() != nil || o.RoundingError() != nil {
+		t.Errorf("1/0：%+v", o)
+	}
+	for _, expr := range []string{"", "0.1", "abc+1", "1+", "1++"} {
+		if _, err := ParseOp(expr, 64); !errors.Is(err, ErrSyntax) {
+			t.Errorf("ParseOp(%q)：错误为 %v", expr, err)
+		}
+	}
+}
+
+func TestLiteralError(t *testing.T) {
+	if got := RatString(LiteralError("0.1", 64)); got != "0.0000000000000000055511151231257827021181583404541015625" {
+		t.Errorf("0.1 的存储误差 = %s", got)
+	}
+	if got := LiteralError("-0.5", 32); got == nil || got.Sign() != 0 {
+		t.Errorf("-0.5 的存储误差 = %v", got)
+	}
+	// 溢出为无穷大的字面值没有有限的误差
+	for _, tt := range []struct {
+		s       string
+		bitSize int
+	}{{"1e400", 64}, {"-1e400", 64}, {"1e39", 32}, {"Inf", 64}, {"abc", 64}} {
+		if got := LiteralError(tt.s, tt.bitSize); got != nil {
+			t.Errorf("LiteralError(%q, %d) = %v，期望 nil", tt.s, tt.bitSize, got)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package ieee754
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ErrSyntax 表达式格式错误
+var ErrSyntax = errors.New("ieee754: 表达式格式错误")
+
+// Op 一次浮点运算 X 运算符 Y
+type Op struct {
+	XText, YText string  // 输入的十进制字面值，如 "0.1"
+	X, Y         float64 // 字面值实际存储的浮点数
+	Operator     byte    // '+'、'-'、'*' 或 '/'
+	BitSize      int     // 32 或 64
+	Result       float64 // 浮点运算的结果（已舍入到 BitSize 位）
+}
+
+// ParseOp 解析 "0.1+0.2" 这样的表达式并按 bitSize 位浮点数计算。运算符两侧可以有空格，
+// 操作数可以带符号和指数，如 "-1.5e-3 * 2"。
+func ParseOp(expr string, bitSize int) (Op, error) {
+	s := strings.TrimSpace(expr)
+	i := operatorIndex(s)
+	if i < 0 {
+		return Op{}, fmt.Errorf("%w：%q 中没有运算符（+ - * /）", ErrSyntax, expr)
+	}
+	o := Op{
+		XText:    strings.TrimSpace(s[:i]),
+		YText:    strings.TrimSpace(s[i+1:]),
+		Operator: s[i],
+		BitSize:  bitSize,
+	}
+	var err error
+	if o.X, err = ParseFloat(o.XText, bitSize); err != nil {
+		return Op{}, err
+	}
+	if o.Y, err = ParseFloat(o.YText, bitSize); err != nil {
+		return Op{}, err
+	}
+	if bitSize == 32 {
+		x, y := float32(o.X), float32(o.Y)
+		var r float32
+		switch o.Operator {
+		case '+':
+			r = x + y
+		case '-':
+			r = x - y
+		case '*':
+			r = x * y
+		case '/':
+			r = x / y
+		}
+		o.Result = float64(r)
+		return o, nil
+	}
+	switch o.Operator {
+	case '+':
+		o.Result = o.X + o.Y
+	case '-':
+		o.Result = o.X - o.Y
+	case '*':
+		o.Result = o.X * o.Y
+	case '/':
+		o.Result = o.X / o.Y
+	}
+	return o, nil
+}
+
+// operatorIndex 第一个二元运算符的下标：跳过开头的符号，以及指数中的符号（如 1e-3 中的 -）
+func operatorIndex(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '*', '/':
+			return i
+		case '+', '-':
+			prev := strings.TrimRight(s[:i], " ")
+			if prev == "" {
+				continue
+			}
+			// 十进制的指数以 e 开头，十六进制（0x1p-3）以 p 开头
+			hex := strings.ContainsAny(prev, "xX")
+			if c := prev[len(prev)-1]; prev == s[:i] && (!hex && (c == 'e' || c == 'E') || hex && (c == 'p' || c == 'P')) {
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+// ParseFloat 与 strconv.ParseFloat 相同，错误包装 ErrSyntax
+func ParseFloat(s string, bitSize int) (float64, error) {
+	f, err := strconv.ParseFloat(s, bitSize)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return 0, fmt.Errorf("%w：%q 不是有效的数", ErrSyntax, s)
+	}
+	return f, nil
+}
+
+// String 如 "0.1 + 0.2"
+func (o Op) String() string { return o.XText + " " + string(o.Operator) + " " + o.YText }
+
+// Exact 对 X、Y 的存储值做精确运算的结果。
+// 结果与 Result 的差就是这一步运算的舍入误差。操作数有无穷大、NaN 或除数为 0 时返回 nil。
+func (o Op) Exact() *big.Rat {
+	return exact(o.X, o.Y, o.Operator)
+}
+
+// Intended 按输入的十进制字面值精确运算的结果，即“本来想算的”值，如 0.1+0.2 为 3/10。
+// 字面值无法精确解析（如 "Inf"）或除数为 0 时返回 nil。
+func (o Op) Intended() *big.Rat {
+	x, ok1 := new(big.Rat).SetString(o.XText)
+	y, ok2 := new(big.Rat).SetString(o.YText)
+	if !ok1 || !ok2 {
+		return nil
+	}
+	return ratOp(x, y, o.Operator)
+}
+
+// RoundingError 这一步运算的舍入误差：Result - Exact()；无法计算时返回 nil
+func (o Op) RoundingError() *big.Rat { return diff(o.Result, o.Exact()) }
+
+// TotalError 最终结果与本来想算的值之差：Result - Intended()，包括字面值存储误差和运算舍入误差
+func (o Op) TotalError() *big.Rat { return diff(o.Result, o.Intended()) }
+
+// LiteralError 十进制字面值 s 按 bitSize 位浮点数存储后的误差：存储值 - s，如 "0.1" 为 +5.55…e-18。
+// s 不是十进制数，或存储时溢出为无穷大（如 float64 的 "1e400"、float32 的 "1e39"）时返回 nil。
+func LiteralError(s string, bitSize int) *big.Rat {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil
+	}
+	f, err := ParseFloat(s, bitSize)
+	if err != nil {
+		return nil
+	}
+	return diff(f, r)
+}
+
+func exact(x, y float64, op byte) *big.Rat {
+	if math.IsInf(x, 0) || math.IsNaN(x) || math.IsInf(y, 0) || math.IsNaN(y) {
+		return nil
+	}
+	return ratOp(new(big.Rat).SetFloat64(x), new(big.Rat).SetFloat64(y), op)
+}
+
+func ratOp(x, y *big.Rat, op byte) *big.Rat {
+	switch op {
+	case '+':
+		return x.Add(x, y)
+	case '-':
+		return x.Sub(x, y)
+	case '*':
+		return x.Mul(x, y)
+	case '/':
+		if y.Sign() == 0 {
+			return nil
+		}
+		return x.Quo(x, y)
+	}
+	return nil
+}
+
+func diff(f float64, r *big.Rat) *big.Rat {
+	if r == nil || math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil
+	}
+	d := new(big.Rat).SetFloat64(f)
+	return d.Sub(d, r)
+}
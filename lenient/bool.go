@@ -0,0 +1,37 @@
+package lenient
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseBool 按 Default 的规则解析布尔值
+func ParseBool(s string) (bool, error) { return Default.ParseBool(s) }
+
+// ParseBool 解析布尔值：按规则去掉首尾空白、转换全角字符后，不区分大小写地在词表中查找
+func (o Options) ParseBool(s string) (bool, error) {
+	start, end, err := o.trim("ParseBool", s)
+	if err != nil {
+		return false, err
+	}
+	word := s[start:end]
+	if o.FullWidth {
+		word = strings.Map(func(r rune) rune { r, _ = fold(r); return r }, word)
+	}
+	if o.True == nil && o.False == nil {
+		if b, err := strconv.ParseBool(word); err == nil {
+			return b, nil
+		}
+	}
+	for _, w := range o.True {
+		if strings.EqualFold(word, w) {
+			return true, nil
+		}
+	}
+	for _, w := range o.False {
+		if strings.EqualFold(word, w) {
+			return false, nil
+		}
+	}
+	return false, syntaxError("ParseBool", s, RuleVocabulary, start, "的 "+strconv.Quote(s[start:end])+" 不在布尔词表中")
+}
@@ -0,0 +1,128 @@
+// Package lenient 在 strconv 的基础上宽松地解析布尔值和数字，宽松程度可配置。
+//
+// strconv 只接受 "true"、"1" 这样的标准写法，配置文件中常见的写法都会报错：
+//
+//	"yes"、"On"、"是"、"否"     布尔词表
+//	" 42 "                     首尾空白
+//	"1,000"、"1_000"            千位分隔符、下划线
+//	"０１２"                    全角数字
+//
+// 解析失败时返回 *Error，指出是哪条规则拒绝了输入、出错位置在原始输入中的字节下标。
+package lenient
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Options 宽松解析的规则，零值与 strconv 一样严格
+type Options struct {
+	TrimSpace    bool     // 去掉首尾空白（包括全角空格）
+	True, False  []string // 布尔词表，不区分大小写；都为空时使用 strconv.ParseBool 的写法
+	ThousandsSep bool     // 允许整数部分用逗号按三位分组，如 "1,234,567"
+	Underscores  bool     // 允许数字之间的下划线，如 "1_000_000"
+	FullWidth    bool     // 把全角字符（"０１２"、"－"、"．"、"ＹＥＳ"）转换为半角
+}
+
+// 默认的布尔词表
+var (
+	DefaultTrue  = []string{"1", "t", "true", "y", "yes", "on", "是", "真", "对", "开"}
+	DefaultFalse = []string{"0", "f", "false", "n", "no", "off", "否", "假", "错", "关"}
+)
+
+// Default 开启全部宽松规则的配置，包级函数 ParseBool、ParseInt 等使用它
+var Default = Options{
+	TrimSpace:    true,
+	True:         DefaultTrue,
+	False:        DefaultFalse,
+	ThousandsSep: true,
+	Underscores:  true,
+	FullWidth:    true,
+}
+
+// Rule 拒绝输入的规则
+type Rule int
+
+const (
+	RuleSyntax     Rule = iota // 不是合法的数字
+	RuleEmpty                  // 输入为空（或只有空白）
+	RuleSpace                  // 首尾有空白，且未开启 TrimSpace
+	RuleVocabulary             // 不在布尔词表中
+	RuleFullWidth              // 全角字符，且未开启 FullWidth
+	RuleUnderscore             // 下划线不在两个数字之间，或未开启 Underscores
+	RuleSeparator              // 千位分隔符位置不对，或未开启 ThousandsSep
+	RuleRange                  // 超出目标类型的范围
+)
+
+var ruleNames = [...]string{
+	RuleSyntax:     "语法",
+	RuleEmpty:      "空输入",
+	RuleSpace:      "首尾空白",
+	RuleVocabulary: "布尔词表",
+	RuleFullWidth:  "全角字符",
+	RuleUnderscore: "下划线",
+	RuleSeparator:  "千位分隔符",
+	RuleRange:      "范围",
+}
+
+func (r Rule) String() string { return ruleNames[r] }
+
+// Error 解析失败的详细信息
+type Error struct {
+	Func   string // 出错的函数，如 "ParseInt"
+	Input  string // 原始输入
+	Rule   Rule   // 拒绝输入的规则
+	Offset int    // 出错位置在 Input 中的字节下标，等于 len(Input) 表示输入提前结束
+	Reason string // 具体原因
+	Err    error  // strconv.ErrRange（Rule 为 RuleRange 时）或 strconv.ErrSyntax
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("lenient.%s: 解析 %q 失败：第 %d 字节处%s（规则：%s）", e.Func, e.Input, e.Offset, e.Reason, e.Rule)
+}
+
+// Unwrap 返回 strconv.ErrSyntax 或 strconv.ErrRange，可以用 errors.Is 判断
+func (e *Error) Unwrap() error { return e.Err }
+
+func syntaxError(fn, input string, rule Rule, offset int, reason string) *Error {
+	return &Error{Func: fn, Input: input, Rule: rule, Offset: offset, Reason: reason, Err: strconv.ErrSyntax}
+}
+
+// trim 按 TrimSpace 处理首尾空白，返回有效内容的字节区间 [start, end)
+func (o Options) trim(fn, s string) (start, end int, err error) {
+	start, end = 0, len(s)
+	for start < end {
+		r, n := utf8.DecodeRuneInString(s[start:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		if !o.TrimSpace {
+			return 0, 0, syntaxError(fn, s, RuleSpace, start, "有空白")
+		}
+		start += n
+	}
+	for end > start {
+		r, n := utf8.DecodeLastRuneInString(s[:end])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		if !o.TrimSpace {
+			return 0, 0, syntaxError(fn, s, RuleSpace, end-n, "有空白")
+		}
+		end -= n
+	}
+	if start == end {
+		return 0, 0, syntaxError(fn, s, RuleEmpty, start, "没有内容")
+	}
+	return start, end, nil
+}
+
+// fold 把全角 ASCII（U+FF01 ~ U+FF5E）转换为对应的半角字符
+func fold(r rune) (rune, bool) {
+	if r >= 0xFF01 && r <= 0xFF5E {
+		return r - 0xFEE0, true
+	}
+	return r, false
+}
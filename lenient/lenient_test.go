@@ -0,0 +1,121 @@
+package lenient
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestParseBool(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want bool
+	}{
+		{"true", true}, {"False", false}, {"yes", true}, {"On", true}, {"OFF", false},
+		{"是", true}, {"否", false}, {" 1 ", true}, {"　n\t", false}, {"ＹＥＳ", true},
+	} {
+		got, err := ParseBool(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseBool(%q) = %t, %v，期望 %t", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want int64
+	}{
+		{"42", 42}, {" 42 ", 42}, {"+7", 7}, {"-1,000", -1000}, {"1,234,567", 1234567},
+		{"1_000_000", 1000000}, {"０１２", 12}, {"－３，０００", -3000}, {"9,223,372,036,854,775,807", math.MaxInt64},
+	} {
+		got, err := ParseInt(tt.in, 64)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseInt(%q) = %d, %v，期望 %d", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseFloat(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want float64
+	}{
+		{"3.14", 3.14}, {"1,234.5", 1234.5}, {"１．５", 1.5}, {" -2e3 ", -2000}, {"1_000.000_1", 1000.0001}, {".5", 0.5},
+	} {
+		got, err := ParseFloat(tt.in, 64)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseFloat(%q) = %v, %v，期望 %v", tt.in, got, err, tt.want)
+		}
+	}
+	if got, err := ParseFloat("inf", 64); err != nil || !math.IsInf(got, 1) {
+		t.Errorf("ParseFloat(\"inf\") = %v, %v", got, err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	strict := Options{}
+	for _, tt := range []struct {
+		name   string
+		parse  func() error
+		rule   Rule
+		offset int
+	}{
+		{"非数字", func() error { _, err := ParseInt("abc123", 0); return err }, RuleSyntax, 0},
+		{"中间的字母", func() error { _, err := ParseInt("12x3", 0); return err }, RuleSyntax, 2},
+		{"中文", func() error { _, err := ParseInt("12三", 0); return err }, RuleSyntax, 2},
+		{"只有符号", func() error { _, err := ParseInt(" - ", 0); return err }, RuleSyntax, 2},
+		{"空输入", func() error { _, err := ParseInt("  ", 0); return err }, RuleEmpty, 2},
+		{"分组不对", func() error { _, err := ParseInt("1,00", 0); return err }, RuleSeparator, 1},
+		{"第一组太长", func() error { _, err := ParseInt("1000,000", 0); return err }, RuleSeparator, 4},
+		{"中间分组", func() error { _, err := ParseInt("1,0000,000", 0); return err }, RuleSeparator, 6},
+		{"小数部分的逗号", func() error { _, err := ParseFloat("1.000,5", 64); return err }, RuleSeparator, 5},
+		{"下划线开头", func() error { _, err := ParseInt("_1", 0); return err }, RuleUnderscore, 0},
+		{"连续下划线", func() error { _, err := ParseInt("1__0", 0); return err }, RuleUnderscore, 1},
+		{"全角偏移", func() error { _, err := ParseInt("１２x", 0); return err }, RuleSyntax, 6},
+		{"溢出", func() error { _, err := ParseInt(" 128", 8); return err }, RuleRange, 1},
+		{"负的无符号数", func() error { _, err := ParseUint("-1", 64); return err }, RuleSyntax, 0},
+		{"指数缺少数字", func() error { _, err := ParseFloat("1e+", 64); return err }, RuleSyntax, 3},
+		{"不在词表", func() error { _, err := ParseBool(" maybe"); return err }, RuleVocabulary, 1},
+		{"严格：空白", func() error { _, err := strict.ParseInt(" 42", 0); return err }, RuleSpace, 0},
+		{"严格：尾部空白", func() error { _, err := strict.ParseInt("42 ", 0); return err }, RuleSpace, 2},
+		{"严格：千位分隔符", func() error { _, err := strict.ParseInt("1,000", 0); return err }, RuleSeparator, 1},
+		{"严格：下划线", func() error { _, err := strict.ParseInt("1_000", 0); return err }, RuleUnderscore, 1},
+		{"严格：全角", func() error { _, err := strict.ParseInt("4２", 0); return err }, RuleFullWidth, 1},
+		{"严格：词表", func() error { _, err := strict.ParseBool("yes"); return err }, RuleVocabulary, 0},
+	} {
+		err := tt.parse()
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Errorf("%s：错误 %v 不是 *Error", tt.name, err)
+			continue
+		}
+		if e.Rule != tt.rule || e.Offset != tt.offset {
+			t.Errorf("%s：规则 %s、位置 %d，期望 %s、%d（%v）", tt.name, e.Rule, e.Offset, tt.rule, tt.offset, err)
+		}
+		want := strconv.ErrSyntax
+		if tt.rule == RuleRange {
+			want = strconv.ErrRange
+		}
+		if !errors.Is(err, want) {
+			t.Errorf("%s：errors.Is(err, %v) 为 false", tt.name, want)
+		}
+	}
+}
+
+func TestStrictMatchesStrconv(t *testing.T) {
+	var strict Options
+	for _, s := range []string{"0", "-12", "+12", "9223372036854775808", "1e3", "", "0x10", "True", "T", "yes"} {
+		got, err := strict.ParseInt(s, 64)
+		want, wantErr := strconv.ParseInt(s, 10, 64)
+		if got != want || (err == nil) != (wantErr == nil) {
+			t.Errorf("Options{}.ParseInt(%q) = %d, %v；strconv：%d, %v", s, got, err, want, wantErr)
+		}
+		b, err := strict.ParseBool(s)
+		wantB, wantErr := strconv.ParseBool(s)
+		if b != wantB || (err == nil) != (wantErr == nil) {
+			t.Errorf("Options{}.ParseBool(%q) = %t, %v；strconv：%t, %v", s, b, err, wantB, wantErr)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package lenient
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ParseInt 按 Default 的规则解析十进制整数，bitSize 的含义与 strconv.ParseInt 相同
+func ParseInt(s string, bitSize int) (int64, error) { return Default.ParseInt(s, bitSize) }
+
+// ParseUint 按 Default 的规则解析十进制无符号整数
+func ParseUint(s string, bitSize int) (uint64, error) { return Default.ParseUint(s, bitSize) }
+
+// ParseFloat 按 Default 的规则解析浮点数
+func ParseFloat(s string, bitSize int) (float64, error) { return Default.ParseFloat(s, bitSize) }
+
+// ParseInt 解析十进制整数。超出范围时与 strconv 一样返回最接近的可表示值和 RuleRange 错误。
+func (o Options) ParseInt(s string, bitSize int) (int64, error) {
+	const fn = "ParseInt"
+	n, err := o.normalize(fn, s)
+	if err != nil {
+		return 0, err
+	}
+	if k, ok := scanInt(n.buf); !ok {
+		return 0, n.syntaxError(fn, s, k)
+	}
+	v, err := strconv.ParseInt(string(n.buf), 10, bitSize)
+	return v, n.convError(fn, s, err, "int", bitSize)
+}
+
+// ParseUint 解析十进制无符号整数，允许前导 "+"
+func (o Options) ParseUint(s string, bitSize int) (uint64, error) {
+	const fn = "ParseUint"
+	n, err := o.normalize(fn, s)
+	if err != nil {
+		return 0, err
+	}
+	if len(n.buf) > 0 && n.buf[0] == '-' {
+		return 0, syntaxError(fn, s, RuleSyntax, n.pos[0], "有负号，无符号整数不能为负")
+	}
+	if k, ok := scanInt(n.buf); !ok {
+		return 0, n.syntaxError(fn, s, k)
+	}
+	digits := n.buf
+	if digits[0] == '+' {
+		digits = digits[1:]
+	}
+	v, err := strconv.ParseUint(string(digits), 10, bitSize)
+	return v, n.convError(fn, s, err, "uint", bitSize)
+}
+
+// ParseFloat 解析浮点数，千位分隔符只能出现在整数部分。
+// 超出范围时与 strconv 一样返回 ±Inf 和 RuleRange 错误。
+func (o Options) ParseFloat(s string, bitSize int) (float64, error) {
+	const fn = "ParseFloat"
+	n, err := o.normalize(fn, s)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseFloat(string(n.buf), bitSize)
+	if errors.Is(err, strconv.ErrSyntax) {
+		return 0, n.syntaxError(fn, s, scanFloat(n.buf))
+	}
+	return v, n.convError(fn, s, err, "float", bitSize)
+}
+
+// number 规范化后的输入：全角转半角，去掉下划线和千位分隔符
+type number struct {
+	buf   []byte // 规范化后的 ASCII 字符
+	pos   []int  // buf[i] 在原始输入中的字节下标
+	start int    // 有效内容在原始输入中的起止下标
+	end   int
+}
+
+func (o Options) normalize(fn, s string) (number, error) {
+	start, end, err := o.trim(fn, s)
+	if err != nil {
+		return number{}, err
+	}
+	n := number{start: start, end: end}
+	for i := start; i < end; {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if f, ok := fold(r); ok {
+			if !o.FullWidth {
+				return number{}, syntaxError(fn, s, RuleFullWidth, i, fmt.Sprintf("的 %q 是全角字符", r))
+			}
+			r = f
+		}
+		if r >= utf8.RuneSelf {
+			return number{}, syntaxError(fn, s, RuleSyntax, i, fmt.Sprintf("的 %q 不是数字", r))
+		}
+		n.buf = append(n.buf, byte(r))
+		n.pos = append(n.pos, i)
+		i += size
+	}
+	return n, n.removeSeparators(o, fn, s)
+}
+
+// removeSeparators 检查并去掉下划线和千位分隔符。
+// 下划线必须夹在两个数字之间；千位分隔符只能出现在整数部分，第一组 1~3 位，之后每组 3 位。
+func (n *number) removeSeparators(o Options, fn, s string) error {
+	buf, pos := n.buf[:0], n.pos[:0]
+	inInt := true         // 是否还在整数部分
+	group, commas := 0, 0 // 当前分组的数字个数、已出现的逗号个数
+	lastComma := 0        // 最后一个逗号在原始输入中的下标
+	endInt := func() error {
+		if inInt && commas > 0 && group != 3 {
+			return syntaxError(fn, s, RuleSeparator, lastComma, "的千位分隔符之后应为 3 位数字")
+		}
+		inInt = false
+		return nil
+	}
+	for i, c := range n.buf {
+		off := n.pos[i]
+		switch {
+		case isDigit(c):
+			group++
+		case c == '_':
+			if !o.Underscores {
+				return syntaxError(fn, s, RuleUnderscore, off, "有下划线")
+			}
+			if i == 0 || !isDigit(n.buf[i-1]) || i+1 == len(n.buf) || !isDigit(n.buf[i+1]) {
+				return syntaxError(fn, s, RuleUnderscore, off, "的下划线不在两个数字之间")
+			}
+			continue
+		case c == ',':
+			if !o.ThousandsSep {
+				return syntaxError(fn, s, RuleSeparator, off, "有千位分隔符")
+			}
+			if !inInt {
+				return syntaxError(fn, s, RuleSeparator, off, "的千位分隔符不在整数部分")
+			}
+			if group == 0 || group > 3 || commas > 0 && group != 3 {
+				return syntaxError(fn, s, RuleSeparator, off, "的千位分隔符没有按三位分组")
+			}
+			group = 0
+			commas++
+			lastComma = off
+			continue
+		case i == 0 && (c == '+' || c == '-'):
+		default:
+			if err := endInt(); err != nil {
+				return err
+			}
+		}
+		buf = append(buf, c)
+		pos = append(pos, off)
+	}
+	n.buf, n.pos = buf, pos
+	return endInt()
+}
+
+// syntaxError 规范化后第 k 个字符处的语法错误，k == len(buf) 表示输入提前结束
+func (n *number) syntaxError(fn, s string, k int) *Error {
+	if k >= len(n.buf) {
+		return syntaxError(fn, s, RuleSyntax, n.end, "缺少数字")
+	}
+	r, _ := utf8.DecodeRuneInString(s[n.pos[k]:])
+	return syntaxError(fn, s, RuleSyntax, n.pos[k], fmt.Sprintf("的 %q 不是数字", r))
+}
+
+// convError 把 strconv 的错误转换为 *Error，bitSize 不合法等其他错误原样返回
+func (n *number) convError(fn, s string, err error, kind string, bitSize int) error {
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, strconv.ErrRange) {
+		return err
+	}
+	typ := kind
+	if bitSize != 0 {
+		typ = fmt.Sprintf("%s%d", kind, bitSize)
+	}
+	return &Error{Func: fn, Input: s, Rule: RuleRange, Offset: n.start,
+		Reason: "的数值超出 " + typ + " 的范围", Err: strconv.ErrRange}
+}
+
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
+
+// scanInt 检查 b 是否为可选的正负号加至少一位数字，返回合法前缀的长度
+func scanInt(b []byte) (k int, ok bool) {
+	k = scanDigits(b)
+	return k, k == len(b) && k > 0 && isDigit(b[k-1])
+}
+
+// scanDigits 可选的正负号加若干数字的前缀长度
+func scanDigits(b []byte) int {
+	i := 0
+	if len(b) > 0 && (b[0] == '+' || b[0] == '-') {
+		i++
+	}
+	for i < len(b) && isDigit(b[i]) {
+		i++
+	}
+	return i
+}
+
+// scanFloat 返回 b 中合法十进制浮点数前缀的长度，用于定位语法错误
+func scanFloat(b []byte) int {
+	i := scanDigits(b)
+	digits := i > 0 && isDigit(b[i-1])
+	if i < len(b) && b[i] == '.' {
+		i++
+		for i < len(b) && isDigit(b[i]) {
+			i++
+			digits = true
+		}
+	}
+	if !digits {
+		return i
+	}
+	if i < len(b) && (b[i] == 'e' || b[i] == 'E') {
+		j := scanDigits(b[i+1:])
+		if j == 0 || !isDigit(b[i+j]) {
+			return i + 1 + j
+		}
+		i += 1 + j
+	}
+	return i
+}
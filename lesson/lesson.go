@@ -0,0 +1,126 @@
+// Package lesson 是课程注册表。
+// basic/ 下的每个课程文件在 init 中把自己和各个小节函数注册进来，
+// 运行器和测试统一通过 id 查找、执行，不再依赖每个文件各自的 main()。
+package lesson
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Section 课程中的一个小节，对应一个无参函数（如 ptrSection2）
+type Section struct {
+	ID    string // 小节 id，即函数名，如 "ptrSection2"
+	Title string // 小节标题
+	Run   func()
+}
+
+// Lesson 一节课，对应 basic/ 下的一个源文件
+type Lesson struct {
+	ID       string // 课程 id，即文件名去掉 .go，如 "4_pointer"
+	Title    string // 课程标题
+	File     string // 源文件路径，注册时自动记录
+	Sections []Section
+}
+
+var (
+	mu      sync.RWMutex
+	lessons = map[string]*Lesson{}
+)
+
+// Register 注册一节课，一般在课程文件的 init 中调用。
+// id 为空、id 重复或小节 id 重复都属于编程错误，直接 panic。
+func Register(l Lesson) {
+	if l.ID == "" {
+		panic("lesson: 课程 id 不能为空")
+	}
+	if l.File == "" {
+		if _, file, _, ok := runtime.Caller(1); ok {
+			l.File = file
+		}
+	}
+	seen := make(map[string]bool, len(l.Sections))
+	for _, s := range l.Sections {
+		if s.ID == "" || s.Run == nil {
+			panic(fmt.Sprintf("lesson: 课程 %s 中存在空的小节", l.ID))
+		}
+		if seen[s.ID] {
+			panic(fmt.Sprintf("lesson: 课程 %s 中小节 %s 重复注册", l.ID, s.ID))
+		}
+		seen[s.ID] = true
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if _, dup := lessons[l.ID]; dup {
+		panic(fmt.Sprintf("lesson: 课程 %s 重复注册", l.ID))
+	}
+	lessons[l.ID] = &l
+}
+
+// All 按课程编号顺序返回所有已注册的课程
+func All() []*Lesson {
+	mu.RLock()
+	defer mu.RUnlock()
+	all := make([]*Lesson, 0, len(lessons))
+	for _, l := range lessons {
+		all = append(all, l)
+	}
+	sort.Slice(all, func(i, j int) bool { return less(all[i].ID, all[j].ID) })
+	return all
+}
+
+// Get 按 id 查找课程
+func Get(id string) (*Lesson, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	l, ok := lessons[id]
+	return l, ok
+}
+
+// Section 按 id 查找本课中的小节
+func (l *Lesson) Section(id string) (*Section, bool) {
+	for i := range l.Sections {
+		if l.Sections[i].ID == id {
+			return &l.Sections[i], true
+		}
+	}
+	return nil, false
+}
+
+// Find 解析 "4_pointer" 或 "4_pointer/ptrSection2" 形式的路径。
+// 只指定课程时返回的 section 为 nil。
+func Find(path string) (*Lesson, *Section, error) {
+	lessonID, sectionID, hasSection := strings.Cut(path, "/")
+	l, ok := Get(lessonID)
+	if !ok {
+		return nil, nil, fmt.Errorf("lesson: 未知课程 %q", lessonID)
+	}
+	if !hasSection {
+		return l, nil, nil
+	}
+	s, ok := l.Section(sectionID)
+	if !ok {
+		return nil, nil, fmt.Errorf("lesson: 课程 %s 中没有小节 %q", lessonID, sectionID)
+	}
+	return l, s, nil
+}
+
+// less 按 id 的数字前缀排序，保证 "10_xxx" 排在 "9_xxx" 之后
+func less(a, b string) bool {
+	na, errA := strconv.Atoi(prefix(a))
+	nb, errB := strconv.Atoi(prefix(b))
+	if errA == nil && errB == nil && na != nb {
+		return na < nb
+	}
+	return a < b
+}
+
+func prefix(id string) string {
+	p, _, _ := strings.Cut(id, "_")
+	return p
+}
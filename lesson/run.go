@@ -0,0 +1,87 @@
+package lesson
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"runtime/debug"
+	"sync"
+)
+
+// PanicError 小节运行过程中发生了 panic
+type PanicError struct {
+	Section string // 小节 id
+	Value   any    // recover 得到的值
+	Stack   []byte // 发生 panic 时的调用栈
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("小节 %s 发生 panic：%v", e.Section, e.Value)
+}
+
+// 运行时会临时替换 os.Stdout，同一时刻只能运行一个小节
+var execMu sync.Mutex
+
+// Exec 运行一个小节。
+// 小节里的代码直接用 fmt.Println 打印到标准输出，w 不为 nil 时这些输出会被转写到 w
+// （如 io.Discard 用于静默运行，bytes.Buffer 用于测试比对）。
+// 小节中的 panic 会被恢复，并以 *PanicError 返回。
+func Exec(s *Section, w io.Writer) error {
+	execMu.Lock()
+	defer execMu.Unlock()
+	if w == nil {
+		return call(s)
+	}
+
+	r, pw, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	copied := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(w, r)
+		r.Close()
+		copied <- err
+	}()
+
+	stdout := os.Stdout
+	os.Stdout = pw
+	err = call(s)
+	os.Stdout = stdout
+	pw.Close()
+
+	if cerr := <-copied; err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func call(s *Section) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = &PanicError{Section: s.ID, Value: v, Stack: debug.Stack()}
+		}
+	}()
+	s.Run()
+	return nil
+}
+
+// masks 每次运行都会变化的输出（内存地址、耗时）及其替换文本
+var masks = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(`0x[0-9a-f]{6,}`), "0x<地址>"},
+	{regexp.MustCompile(`耗时：[0-9.]+[a-zµ]+`), "耗时：<耗时>"},
+	{regexp.MustCompile(`快：[0-9.]+倍`), "快：<倍数>倍"},
+}
+
+// Normalize 把小节输出中每次运行都会变化的内容（内存地址、耗时）替换为固定文本，
+// 用于 golden 测试和不同架构间的输出对比
+func Normalize(out string) string {
+	for _, m := range masks {
+		out = m.re.ReplaceAllString(out, m.repl)
+	}
+	return out
+}
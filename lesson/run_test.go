@@ -0,0 +1,15 @@
+package lesson
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	for _, tt := range []struct{ in, want string }{
+		{"p = 0xc000012345，耗时：1.5ms", "p = 0x<地址>，耗时：<耗时>"},
+		{"每次耗时：850.25ns，快：12.34倍", "每次耗时：<耗时>，快：<倍数>倍"},
+		{"十六进制：0xFF0000，0x1f", "十六进制：0xFF0000，0x1f"},
+	} {
+		if got := Normalize(tt.in); got != tt.want {
+			t.Errorf("Normalize(%q) = %q，期望 %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Allocate 把 m 平均分成 n 份，除不尽的最小单位从第一份开始每份多分一个，
+// 如 10.00 分成 3 份为 3.34、3.33、3.33。各份之和严格等于 m。
+func (m Money) Allocate(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("money: 份数必须大于 0，实际为 %d", n)
+	}
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.AllocateRatios(ratios...)
+}
+
+// AllocateRatios 按比例拆分 m，如 AllocateRatios(70, 20, 10)。
+// 每份先按比例向零取整，剩下的最小单位按最大余数法分配：余数大的份先分，余数相同时靠前的先分。
+// 比例不能为负，且不能全为 0。负数金额拆分为负数的各份。各份之和严格等于 m。
+func (m Money) AllocateRatios(ratios ...int64) ([]Money, error) {
+	if len(ratios) == 0 {
+		return nil, errors.New("money: 没有指定拆分比例")
+	}
+	total := new(big.Int)
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, fmt.Errorf("money: 拆分比例不能为负，实际为 %d", r)
+		}
+		total.Add(total, big.NewInt(r))
+	}
+	if total.Sign() == 0 {
+		return nil, errors.New("money: 拆分比例不能全为 0")
+	}
+
+	// 按绝对值拆分，最后再加上符号；-2⁶³ 的绝对值超出 int64，用 big.Int 计算
+	amount := new(big.Int).Abs(big.NewInt(m.minor))
+	shares := make([]*big.Int, len(ratios))
+	rems := make([]*big.Int, len(ratios))
+	left := new(big.Int).Set(amount)
+	for i, r := range ratios {
+		n := new(big.Int).Mul(amount, big.NewInt(r))
+		shares[i], rems[i] = n.QuoRem(n, total, new(big.Int))
+		left.Sub(left, shares[i])
+	}
+	// left < len(ratios)，依次给余数最大的份各加 1
+	for k := left.Int64(); k > 0; k-- {
+		best := -1
+		for i := range rems {
+			if rems[i].Sign() >= 0 && ratios[i] > 0 && (best < 0 || rems[i].Cmp(rems[best]) > 0) {
+				best = i
+			}
+		}
+		shares[best].Add(shares[best], big.NewInt(1))
+		rems[best].SetInt64(-1) // 每份最多多分一个
+	}
+
+	out := make([]Money, len(ratios))
+	for i, s := range shares {
+		if m.minor < 0 {
+			s.Neg(s)
+		}
+		out[i] = Money{s.Int64(), m.currency}
+	}
+	return out, nil
+}
@@ -0,0 +1,257 @@
+// Package money 用 int64 记录最小货币单位（如分）的金额类型。
+//
+// 3_int_float.go 建议“用整型存储金额（分）”，但换算成元时又写成了 float64(amountCent) / 100，
+// 重新引入了浮点误差。Money 在所有运算中都只用整数：
+//
+//   - 加减运算检查溢出和币种是否一致
+//   - 乘以利率、折扣等比例时用 decimal 精确计算，再按明确指定的舍入方式（decimal.RoundingMode）取整
+//   - Allocate 把金额按份数或比例拆分，各份之和严格等于原金额，不会多出或丢掉一分钱
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/colayear/go_learning/decimal"
+)
+
+// 运算失败的原因，可以用 errors.Is 判断
+var (
+	ErrOverflow         = errors.New("money: 金额溢出")
+	ErrCurrencyMismatch = errors.New("money: 币种不一致")
+	ErrSyntax           = errors.New("money: 金额格式错误")
+	ErrCurrency         = errors.New("money: 未知币种")
+)
+
+// Currency ISO 4217 币种代码，如 "CNY"
+type Currency string
+
+// minorDigits 各币种最小单位的小数位数，未列出的币种不支持
+var minorDigits = map[Currency]int{
+	"CNY": 2, "HKD": 2, "TWD": 2, "USD": 2, "EUR": 2, "GBP": 2, "AUD": 2, "CAD": 2, "SGD": 2, "CHF": 2,
+	"JPY": 0, "KRW": 0, "VND": 0,
+	"BHD": 3, "KWD": 3, "JOD": 3, "OMR": 3,
+}
+
+// Digits 最小单位的小数位数，如 CNY 为 2（分）、JPY 为 0；未知币种返回 -1
+func (c Currency) Digits() int {
+	if d, ok := minorDigits[c]; ok {
+		return d
+	}
+	return -1
+}
+
+// Money 金额，零值是没有币种的 0
+type Money struct {
+	minor    int64 // 最小单位的个数，如 1001 分
+	currency Currency
+}
+
+// New 以最小单位表示的金额，如 New(1001, "CNY") 为 10.01 元。未知币种返回 ErrCurrency。
+func New(minor int64, c Currency) (Money, error) {
+	if c.Digits() < 0 {
+		return Money{}, fmt.Errorf("%w：%q", ErrCurrency, c)
+	}
+	return Money{minor, c}, nil
+}
+
+// MustNew 与 New 相同，但币种未知时 panic，用于常量金额
+func MustNew(minor int64, c Currency) Money {
+	m, err := New(minor, c)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Minor 以最小单位表示的金额
+func (m Money) Minor() int64 { return m.minor }
+
+// Currency 币种
+func (m Money) Currency() Currency { return m.currency }
+
+// IsZero 金额是否为 0
+func (m Money) IsZero() bool { return m.minor == 0 }
+
+// Sign 金额为负、零、正时分别返回 -1、0、1
+func (m Money) Sign() int {
+	switch {
+	case m.minor < 0:
+		return -1
+	case m.minor > 0:
+		return 1
+	}
+	return 0
+}
+
+func (m Money) check(o Money) error {
+	if m.currency != o.currency {
+		return fmt.Errorf("%w：%s 与 %s", ErrCurrencyMismatch, m.currency, o.currency)
+	}
+	return nil
+}
+
+// Add m + o，币种不一致或溢出时返回错误
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.check(o); err != nil {
+		return Money{}, err
+	}
+	s := m.minor + o.minor
+	if (s > m.minor) != (o.minor > 0) {
+		return Money{}, fmt.Errorf("%w：%s + %s", ErrOverflow, m, o)
+	}
+	return Money{s, m.currency}, nil
+}
+
+// Sub m - o，币种不一致或溢出时返回错误
+func (m Money) Sub(o Money) (Money, error) {
+	if err := m.check(o); err != nil {
+		return Money{}, err
+	}
+	d := m.minor - o.minor
+	if (d < m.minor) != (o.minor > 0) {
+		return Money{}, fmt.Errorf("%w：%s - %s", ErrOverflow, m, o)
+	}
+	return Money{d, m.currency}, nil
+}
+
+// Neg -m，对最小的负数取反会溢出
+func (m Money) Neg() (Money, error) {
+	if m.minor == -1<<63 {
+		return Money{}, fmt.Errorf("%w：-(%s)", ErrOverflow, m)
+	}
+	return Money{-m.minor, m.currency}, nil
+}
+
+// Cmp 比较 m 和 o：m < o 返回 -1，相等返回 0，m > o 返回 1。币种不一致时返回错误。
+func (m Money) Cmp(o Money) (int, error) {
+	if err := m.check(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.minor < o.minor:
+		return -1, nil
+	case m.minor > o.minor:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Mul m 乘以十进制比例 rate（如 "0.06"、"1.5"、"-0.125"），结果按 mode 舍入到最小单位。
+// rate 按字符串精确解析，不经过浮点数。
+func (m Money) Mul(rate string, mode decimal.RoundingMode) (Money, error) {
+	r, err := decimal.Parse(rate)
+	if err != nil || !isDecimal(rate) {
+		return Money{}, fmt.Errorf("%w：比例 %q 不是十进制小数", ErrSyntax, rate)
+	}
+	return m.mulRat(r, decimal.New(1, 0), mode, rate)
+}
+
+// isDecimal s 是否为可带正负号的十进制小数，如 "-0.125"
+func isDecimal(s string) bool {
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	digits, dots := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case '0' <= c && c <= '9':
+			digits++
+		case c == '.':
+			dots++
+		default:
+			return false
+		}
+	}
+	return digits > 0 && dots <= 1
+}
+
+// MulRat m 乘以 num/den，结果按 mode 舍入到最小单位，如 MulRat(1, 3, decimal.HalfEven) 取三分之一
+func (m Money) MulRat(num, den int64, mode decimal.RoundingMode) (Money, error) {
+	if den == 0 {
+		return Money{}, errors.New("money: 比例的分母为 0")
+	}
+	return m.mulRat(decimal.New(num, 0), decimal.New(den, 0), mode, fmt.Sprintf("%d/%d", num, den))
+}
+
+// mulRat m × num ÷ den（den 不为 0），先精确相乘，只在最后的除法中舍入一次
+func (m Money) mulRat(num, den decimal.Decimal, mode decimal.RoundingMode, desc string) (Money, error) {
+	r, err := decimal.New(m.minor, 0).Mul(num).Quo(den, 0, mode)
+	if err != nil {
+		return Money{}, err
+	}
+	q := r.Coefficient()
+	if !q.IsInt64() {
+		return Money{}, fmt.Errorf("%w：%s × %s", ErrOverflow, m, desc)
+	}
+	return Money{q.Int64(), m.currency}, nil
+}
+
+// String 按币种的小数位数输出，如 "10.01"、"-0.50"，日元等没有小数的币种输出整数
+func (m Money) String() string {
+	digits := max(m.currency.Digits(), 0)
+	u := uint64(m.minor)
+	sign := ""
+	if m.minor < 0 {
+		sign, u = "-", -u
+	}
+	s := fmt.Sprintf("%0*d", digits+1, u)
+	if digits == 0 {
+		return sign + s
+	}
+	return sign + s[:len(s)-digits] + "." + s[len(s)-digits:]
+}
+
+// Format 带币种代码的写法，如 "CNY 10.01"
+func (m Money) Format() string {
+	return string(m.currency) + " " + m.String()
+}
+
+// Parse 解析 String 的输出，如 Parse("10.01", "CNY")。
+// 小数位数可以少于币种的位数（"10.5" 即 10.50），但不能更多：多出的位数需要舍入，应当由调用方明确处理。
+func Parse(s string, c Currency) (Money, error) {
+	digits := c.Digits()
+	if digits < 0 {
+		return Money{}, fmt.Errorf("%w：%q", ErrCurrency, c)
+	}
+	syntax := func(reason string) (Money, error) {
+		return Money{}, fmt.Errorf("%w：%q %s", ErrSyntax, s, reason)
+	}
+	body := s
+	neg := false
+	if body != "" && (body[0] == '-' || body[0] == '+') {
+		neg = body[0] == '-'
+		body = body[1:]
+	}
+	intPart, frac, hasDot := strings.Cut(body, ".")
+	if intPart == "" || hasDot && frac == "" {
+		return syntax("缺少数字")
+	}
+	if len(frac) > digits {
+		return syntax(fmt.Sprintf("的小数位数超过 %s 的 %d 位", c, digits))
+	}
+	// 累加时以 int64 能表示的最大绝对值 2^63 为上限，u*10 + d 不会超出 uint64
+	const limit = 1 << 63
+	var u uint64
+	for _, part := range []string{intPart, frac + strings.Repeat("0", digits-len(frac))} {
+		for i := 0; i < len(part); i++ {
+			ch := part[i]
+			if ch < '0' || ch > '9' {
+				return syntax(fmt.Sprintf("中的 %q 不是数字", ch))
+			}
+			d := uint64(ch - '0')
+			if u > (limit-d)/10 {
+				return Money{}, fmt.Errorf("%w：%q", ErrOverflow, s)
+			}
+			u = u*10 + d
+		}
+	}
+	switch {
+	case neg && u <= 1<<63:
+		return Money{-int64(u), c}, nil
+	case !neg && u < 1<<63:
+		return Money{int64(u), c}, nil
+	}
+	return Money{}, fmt.Errorf("%w：%q", ErrOverflow, s)
+}
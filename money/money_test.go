@@ -0,0 +1,199 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/colayear/go_learning/decimal"
+)
+
+func cny(minor int64) Money { return MustNew(minor, "CNY") }
+
+func TestAddSub(t *testing.T) {
+	if got, err := cny(1001).Add(cny(99)); err != nil || got != cny(1100) {
+		t.Errorf("Add = %v, %v", got, err)
+	}
+	if got, err := cny(1001).Sub(cny(2000)); err != nil || got != cny(-999) {
+		t.Errorf("Sub = %v, %v", got, err)
+	}
+	for name, err := range map[string]error{
+		"加法上溢": func() error { _, err := cny(math.MaxInt64).Add(cny(1)); return err }(),
+		"加法下溢": func() error { _, err := cny(math.MinInt64).Add(cny(-1)); return err }(),
+		"减法上溢": func() error { _, err := cny(math.MaxInt64).Sub(cny(-1)); return err }(),
+		"减法下溢": func() error { _, err := cny(math.MinInt64).Sub(cny(1)); return err }(),
+		"取反溢出": func() error { _, err := cny(math.MinInt64).Neg(); return err }(),
+	} {
+		if !errors.Is(err, ErrOverflow) {
+			t.Errorf("%s：错误为 %v", name, err)
+		}
+	}
+	if _, err := cny(1).Add(MustNew(1, "USD")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("不同币种相加：错误为 %v", err)
+	}
+	if _, err := New(1, "XXX"); !errors.Is(err, ErrCurrency) {
+		t.Errorf("未知币种：错误为 %v", err)
+	}
+}
+
+func TestMul(t *testing.T) {
+	for _, tt := range []struct {
+		minor int64
+		rate  string
+		mode  decimal.RoundingMode
+		want  int64
+	}{
+		// 25 × 0.1 = 2.5
+		{25, "0.1", decimal.HalfUp, 3}, {25, "0.1", decimal.HalfEven, 2}, {25, "0.1", decimal.Down, 2}, {25, "0.1", decimal.Up, 3},
+		{-25, "0.1", decimal.HalfUp, -3}, {-25, "0.1", decimal.HalfEven, -2}, {-25, "0.1", decimal.Down, -2}, {-25, "0.1", decimal.Up, -3},
+		// 35 × 0.1 = 3.5
+		{35, "0.1", decimal.HalfEven, 4}, {-35, "0.1", decimal.HalfEven, -4},
+		// 21 × 0.1 = 2.1
+		{21, "0.1", decimal.HalfUp, 2}, {21, "0.1", decimal.HalfEven, 2}, {21, "0.1", decimal.Down, 2}, {21, "0.1", decimal.Up, 3},
+		// 29 × 0.1 = 2.9
+		{29, "0.1", decimal.HalfUp, 3}, {29, "0.1", decimal.HalfEven, 3}, {29, "0.1", decimal.Down, 2}, {29, "0.1", decimal.Up, 3},
+		{-29, "0.1", decimal.Down, -2}, {-21, "0.1", decimal.Up, -3},
+		{-21, "0.1", decimal.Ceiling, -2}, {-21, "0.1", decimal.Floor, -3},
+		// 10.01 元的 6% 税：60.06 分
+		{1001, "0.06", decimal.HalfUp, 60}, {1001, "0.06", decimal.Up, 61},
+		{1001, "1.5", decimal.HalfUp, 1502}, {1001, "-0.5", decimal.HalfUp, -501}, {1000, "+2", decimal.Down, 2000},
+		{math.MaxInt64, "1", decimal.Down, math.MaxInt64}, {math.MinInt64, "0.5", decimal.Down, math.MinInt64 / 2},
+	} {
+		got, err := cny(tt.minor).Mul(tt.rate, tt.mode)
+		if err != nil || got.Minor() != tt.want {
+			t.Errorf("%d × %s（%s）= %v, %v，期望 %d", tt.minor, tt.rate, tt.mode, got.Minor(), err, tt.want)
+		}
+	}
+	if got, err := cny(1000).MulRat(1, 3, decimal.HalfEven); err != nil || got.Minor() != 333 {
+		t.Errorf("MulRat(1, 3) = %v, %v", got, err)
+	}
+	if _, err := cny(math.MaxInt64).Mul("1.01", decimal.HalfUp); !errors.Is(err, ErrOverflow) {
+		t.Errorf("乘法溢出：错误为 %v", err)
+	}
+	for _, rate := range []string{"", "abc", "1/3", "1e2", "0x10", "1.2.3", "."} {
+		if _, err := cny(1).Mul(rate, decimal.HalfUp); !errors.Is(err, ErrSyntax) {
+			t.Errorf("Mul(%q)：错误为 %v", rate, err)
+		}
+	}
+}
+
+func TestStringParse(t *testing.T) {
+	for _, tt := range []struct {
+		minor int64
+		cur   Currency
+		want  string
+	}{
+		{1001, "CNY", "10.01"}, {5, "CNY", "0.05"}, {-50, "CNY", "-0.50"}, {0, "CNY", "0.00"},
+		{1001, "JPY", "1001"}, {1001, "BHD", "1.001"},
+		{math.MinInt64, "CNY", "-92233720368547758.08"}, {math.MaxInt64, "CNY", "92233720368547758.07"},
+	} {
+		m := MustNew(tt.minor, tt.cur)
+		if got := m.String(); got != tt.want {
+			t.Errorf("%d %s 的 String() = %q，期望 %q", tt.minor, tt.cur, got, tt.want)
+		}
+		if got, err := Parse(tt.want, tt.cur); err != nil || got != m {
+			t.Errorf("Parse(%q, %s) = %v, %v", tt.want, tt.cur, got, err)
+		}
+	}
+	if got, err := Parse("10.5", "CNY"); err != nil || got.Minor() != 1050 {
+		t.Errorf("Parse(\"10.5\") = %v, %v", got, err)
+	}
+	if got := cny(1001).Format(); got != "CNY 10.01" {
+		t.Errorf("Format() = %q", got)
+	}
+	for _, s := range []string{"", "-", "1.", ".5", "10.001", "1,000", "abc", " 1", "1.5", "92233720368547758.08"} {
+		cur := Currency("CNY")
+		if s == "1.5" {
+			cur = "JPY"
+		}
+		if _, err := Parse(s, cur); !errors.Is(err, ErrSyntax) && !errors.Is(err, ErrOverflow) {
+			t.Errorf("Parse(%q, %s)：错误为 %v", s, cur, err)
+		}
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		cur  Currency
+		want int64 // 溢出时为 0
+	}{
+		{"9223372036854775807", "JPY", math.MaxInt64},
+		{"-9223372036854775808", "JPY", math.MinInt64},
+		{"9223372036854775808", "JPY", 0},
+		{"-9223372036854775809", "JPY", 0},
+		{"18446744073709551615", "JPY", 0},
+		{"18446744073709551616", "JPY", 0},
+		{"18446744073709551619", "JPY", 0},
+		{"184467440737095516.16", "CNY", 0},
+		{"-92233720368547758.09", "CNY", 0},
+		{"99999999999999999999999", "JPY", 0},
+	} {
+		got, err := Parse(tt.s, tt.cur)
+		if tt.want == 0 {
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("Parse(%q, %s) = %v, %v，期望溢出", tt.s, tt.cur, got, err)
+			}
+			continue
+		}
+		if err != nil || got.Minor() != tt.want {
+			t.Errorf("Parse(%q, %s) = %v, %v，期望 %d", tt.s, tt.cur, got, err, tt.want)
+		}
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	parts, err := cny(1000).Allocate(3)
+	if err != nil || parts[0].Minor() != 334 || parts[1].Minor() != 333 || parts[2].Minor() != 333 {
+		t.Errorf("Allocate(3) = %v, %v", parts, err)
+	}
+	parts, err = cny(-1000).Allocate(3)
+	if err != nil || parts[0].Minor() != -334 || parts[2].Minor() != -333 {
+		t.Errorf("负数 Allocate(3) = %v, %v", parts, err)
+	}
+	// 5 分按 1:1:1:... 比例，余数相同时靠前的先分
+	parts, _ = cny(5).AllocateRatios(30, 70)
+	if parts[0].Minor() != 2 || parts[1].Minor() != 3 { // 1.5 和 3.5，余数相同，靠前的多分
+		t.Errorf("AllocateRatios(30, 70) = %v", parts)
+	}
+	parts, _ = cny(100).AllocateRatios(1, 0, 2)
+	if parts[0].Minor() != 33 || parts[1].Minor() != 0 || parts[2].Minor() != 67 {
+		t.Errorf("AllocateRatios(1, 0, 2) = %v", parts)
+	}
+	for _, ratios := range [][]int64{nil, {0, 0}, {1, -1}} {
+		if _, err := cny(100).AllocateRatios(ratios...); err == nil {
+			t.Errorf("AllocateRatios(%v) 应当报错", ratios)
+		}
+	}
+	if _, err := cny(100).Allocate(0); err == nil {
+		t.Error("Allocate(0) 应当报错")
+	}
+
+	r := rand.New(rand.NewPCG(5, 6))
+	for i := 0; i < 2000; i++ {
+		amount := int64(r.Uint64())
+		if i%2 == 0 {
+			amount = r.Int64N(100000) - 50000
+		}
+		ratios := make([]int64, r.IntN(8)+1)
+		for j := range ratios {
+			ratios[j] = r.Int64N(1000)
+		}
+		ratios[0]++
+		parts, err := cny(amount).AllocateRatios(ratios...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sum := int64(0)
+		for j, p := range parts {
+			sum += p.Minor()
+			if ratios[j] == 0 && p.Minor() != 0 {
+				t.Fatalf("比例为 0 的一份分到了 %v", p)
+			}
+		}
+		if sum != amount {
+			t.Fatalf("%d 按 %v 拆分为 %v，合计 %d", amount, ratios, parts, sum)
+		}
+	}
+}
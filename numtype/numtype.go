@@ -0,0 +1,205 @@
+// Package numtype 用反射查询数值类型的种类、大小、对齐、符号和取值范围，以及一个值能否放进该类型。
+//
+// 3_int_float.go 把各整数类型的字节数和范围写在注释和 Printf 里，
+// 并说“int 占用字节随系统变化”。本包在运行时从类型本身读出这些信息，
+// 命名类型（如 type UserID int32）同样适用；golearn types 打印当前 GOARCH 下所有内置数值类型的表格。
+package numtype
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/colayear/go_learning/strutil"
+)
+
+// ErrNotNumeric 类型不是整数、浮点数或复数
+var ErrNotNumeric = errors.New("numtype: 不是数值类型")
+
+// Number 所有底层类型为数值的类型
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~complex64 | ~complex128
+}
+
+// Info 数值类型的信息
+type Info struct {
+	Type   reflect.Type
+	Kind   reflect.Kind // 底层类型，如 UserID 的 Kind 为 reflect.Int32
+	Size   uintptr      // 占用字节数，与 unsafe.Sizeof 相同
+	Align  int          // 作为变量时的对齐字节数，与 unsafe.Alignof 相同
+	Bits   int          // 位数；复数为实部和虚部的位数之和
+	Signed bool         // 能否表示负数；浮点数和复数为 true
+
+	// Min、Max 取值范围，类型为 Type 本身。浮点数为 ±最大有限值（不含无穷大）；复数没有大小关系，为无效的 reflect.Value
+	Min, Max reflect.Value
+}
+
+// Of 查询类型 t 的信息；t 不是数值类型时返回 ErrNotNumeric
+func Of(t reflect.Type) (Info, error) {
+	info := Info{Type: t, Kind: t.Kind(), Size: t.Size(), Align: t.Align(), Bits: int(t.Size()) * 8}
+	min, max := reflect.New(t).Elem(), reflect.New(t).Elem()
+	switch {
+	case isInt(info.Kind):
+		info.Signed = true
+		min.SetInt(-1 << (info.Bits - 1))
+		max.SetInt(1<<(info.Bits-1) - 1)
+	case isUint(info.Kind):
+		max.SetUint(math.MaxUint64 >> (64 - info.Bits))
+	case isFloat(info.Kind):
+		info.Signed = true
+		f := math.MaxFloat64
+		if info.Kind == reflect.Float32 {
+			f = math.MaxFloat32
+		}
+		min.SetFloat(-f)
+		max.SetFloat(f)
+	case isComplex(info.Kind):
+		info.Signed = true
+		return info, nil
+	default:
+		return Info{}, fmt.Errorf("%w：%s", ErrNotNumeric, t)
+	}
+	info.Min, info.Max = min, max
+	return info, nil
+}
+
+// For 类型 T 的信息，如 numtype.For[UserID]()
+func For[T Number]() Info {
+	info, err := Of(reflect.TypeFor[T]())
+	if err != nil {
+		panic(err) // Number 约束保证不会出错
+	}
+	return info
+}
+
+func isInt(k reflect.Kind) bool {
+	return k == reflect.Int || k == reflect.Int8 || k == reflect.Int16 || k == reflect.Int32 || k == reflect.Int64
+}
+
+func isUint(k reflect.Kind) bool {
+	return k == reflect.Uint || k == reflect.Uint8 || k == reflect.Uint16 || k == reflect.Uint32 ||
+		k == reflect.Uint64 || k == reflect.Uintptr
+}
+
+func isFloat(k reflect.Kind) bool { return k == reflect.Float32 || k == reflect.Float64 }
+
+func isComplex(k reflect.Kind) bool { return k == reflect.Complex64 || k == reflect.Complex128 }
+
+// Fits 把数值 v 转换为该类型后值是否不变，即 v 能否放进该类型：
+//
+//	For[int8]().Fits(127)         // true
+//	For[int8]().Fits(128)         // false，超出范围
+//	For[uint]().Fits(-1)          // false
+//	For[int32]().Fits(1.5)        // false，有小数部分
+//	For[float32]().Fits(16777217) // false，float32 只有 24 位尾数，存为 16777216
+//
+// 浮点类型可以放下同精度或更低精度的 NaN 和无穷大；v 不是数值时返回 false。
+func (i Info) Fits(v any) bool {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return false
+	}
+	if isComplex(rv.Kind()) {
+		c := rv.Complex()
+		if isComplex(i.Kind) {
+			return fitsFloat(i.floatKind(), real(c)) && fitsFloat(i.floatKind(), imag(c))
+		}
+		return imag(c) == 0 && i.Fits(real(c))
+	}
+	var r *big.Rat
+	switch k := rv.Kind(); {
+	case isInt(k):
+		r = new(big.Rat).SetInt64(rv.Int())
+	case isUint(k):
+		r = new(big.Rat).SetInt(new(big.Int).SetUint64(rv.Uint()))
+	case isFloat(k):
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return (isFloat(i.Kind) || isComplex(i.Kind)) && fitsFloat(i.floatKind(), f)
+		}
+		r = new(big.Rat).SetFloat64(f)
+	default:
+		return false
+	}
+	switch {
+	case isInt(i.Kind):
+		return r.IsInt() && r.Cmp(new(big.Rat).SetInt64(i.Min.Int())) >= 0 && r.Cmp(new(big.Rat).SetInt64(i.Max.Int())) <= 0
+	case isUint(i.Kind):
+		return r.IsInt() && r.Sign() >= 0 && r.Num().Cmp(new(big.Int).SetUint64(i.Max.Uint())) <= 0
+	case i.floatKind() == reflect.Float32:
+		_, exact := r.Float32()
+		return exact
+	case i.floatKind() == reflect.Float64:
+		_, exact := r.Float64()
+		return exact
+	}
+	return false
+}
+
+// floatKind 浮点数的种类，复数取实部的种类
+func (i Info) floatKind() reflect.Kind {
+	switch i.Kind {
+	case reflect.Complex64:
+		return reflect.Float32
+	case reflect.Complex128:
+		return reflect.Float64
+	}
+	return i.Kind
+}
+
+func fitsFloat(k reflect.Kind, f float64) bool {
+	if k == reflect.Float32 {
+		return float64(float32(f)) == f || math.IsNaN(f)
+	}
+	return k == reflect.Float64
+}
+
+// Builtin 所有内置数值类型。byte 和 rune 分别是 uint8 和 int32 的别名，不单独列出。
+var Builtin = []reflect.Type{
+	reflect.TypeFor[int8](), reflect.TypeFor[int16](), reflect.TypeFor[int32](), reflect.TypeFor[int64](), reflect.TypeFor[int](),
+	reflect.TypeFor[uint8](), reflect.TypeFor[uint16](), reflect.TypeFor[uint32](), reflect.TypeFor[uint64](), reflect.TypeFor[uint](),
+	reflect.TypeFor[uintptr](),
+	reflect.TypeFor[float32](), reflect.TypeFor[float64](), reflect.TypeFor[complex64](), reflect.TypeFor[complex128](),
+}
+
+// WriteTable 把 infos 写成 Markdown 表格：类型、底层类型、字节数、对齐、有无符号、最小值、最大值
+func WriteTable(w io.Writer, infos []Info) {
+	rows := [][]string{{"类型", "底层类型", "字节", "对齐", "符号", "最小值", "最大值"}}
+	for _, info := range infos {
+		sign, min, max := "无符号", "-", "-"
+		if info.Signed {
+			sign = "有符号"
+		}
+		if info.Min.IsValid() {
+			min, max = fmt.Sprint(info.Min), fmt.Sprint(info.Max)
+		}
+		rows = append(rows, []string{info.Type.String(), info.Kind.String(),
+			fmt.Sprint(info.Size), fmt.Sprint(info.Align), sign, min, max})
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], strutil.Width(cell))
+		}
+	}
+	for n, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = strutil.PadRight(cell, widths[i])
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+		if n == 0 {
+			for i := range cells {
+				cells[i] = strings.Repeat("-", widths[i])
+			}
+			fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+		}
+	}
+}
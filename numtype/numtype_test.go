@@ -0,0 +1,104 @@
+package numtype
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+type UserID int32
+
+type Level uint8
+
+func TestOf(t *testing.T) {
+	info := For[UserID]()
+	if info.Kind != reflect.Int32 || info.Size != 4 || info.Align != 4 || info.Bits != 32 || !info.Signed ||
+		info.Min.Interface() != UserID(math.MinInt32) || info.Max.Interface() != UserID(math.MaxInt32) {
+		t.Errorf("For[UserID]() = %+v", info)
+	}
+	if info := For[int](); info.Size != unsafe.Sizeof(int(0)) || info.Max.Int() != math.MaxInt {
+		t.Errorf("For[int]() = %+v", info)
+	}
+	if info := For[uint64](); info.Signed || info.Min.Uint() != 0 || info.Max.Uint() != math.MaxUint64 {
+		t.Errorf("For[uint64]() = %+v", info)
+	}
+	if info := For[float32](); info.Max.Float() != math.MaxFloat32 || info.Min.Float() != -math.MaxFloat32 {
+		t.Errorf("For[float32]() = %+v", info)
+	}
+	if info := For[complex64](); info.Size != 8 || info.Align != 4 || info.Min.IsValid() {
+		t.Errorf("For[complex64]() = %+v", info)
+	}
+	for _, typ := range []reflect.Type{reflect.TypeFor[string](), reflect.TypeFor[bool](), reflect.TypeFor[*int]()} {
+		if _, err := Of(typ); !errors.Is(err, ErrNotNumeric) {
+			t.Errorf("Of(%s)：错误为 %v", typ, err)
+		}
+	}
+	for _, typ := range Builtin {
+		info, err := Of(typ)
+		if err != nil || info.Type != typ {
+			t.Errorf("Of(%s) = %+v, %v", typ, info, err)
+		}
+	}
+}
+
+func TestFits(t *testing.T) {
+	for _, tt := range []struct {
+		info Info
+		v    any
+		want bool
+	}{
+		{For[int8](), 127, true},
+		{For[int8](), 128, false},
+		{For[int8](), int64(-128), true},
+		{For[int8](), -129, false},
+		{For[uint](), -1, false},
+		{For[uint64](), uint64(math.MaxUint64), true},
+		{For[int64](), uint64(math.MaxUint64), false},
+		{For[int32](), 1.5, false},
+		{For[int32](), 2.0, true},
+		{For[int64](), math.Inf(1), false},
+		{For[int](), math.NaN(), false},
+		{For[UserID](), int64(1 << 40), false},
+		{For[UserID](), Level(200), true},
+		{For[Level](), UserID(256), false},
+		{For[float32](), 16777216, true},
+		{For[float32](), 16777217, false},
+		{For[float32](), 0.1, false},
+		{For[float32](), 0.5, true},
+		{For[float32](), math.Inf(-1), true},
+		{For[float32](), 1e300, false},
+		{For[float64](), uint64(1<<53 + 1), false},
+		{For[float64](), math.NaN(), true},
+		{For[complex64](), complex(0.5, 1), true},
+		{For[complex64](), complex(0.1, 1), false},
+		{For[complex128](), 3, true},
+		{For[int](), complex(3, 0), true},
+		{For[int](), complex(3, 1), false},
+		{For[int](), "3", false},
+		{For[int](), nil, false},
+	} {
+		if got := tt.info.Fits(tt.v); got != tt.want {
+			t.Errorf("%s 能否放下 %T(%v)：%t，期望 %t", tt.info.Type, tt.v, tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	var b strings.Builder
+	WriteTable(&b, []Info{For[int8](), For[UserID](), For[complex128]()})
+	for _, want := range []string{
+		"| 类型           | 底层类型   | 字节 | 对齐 | 符号   | 最小值      | 最大值     |",
+		"| int8           | int8       | 1    | 1    | 有符号 | -128        | 127        |",
+		"| numtype.UserID | int32      | 4    | 4    | 有符号 | -2147483648 | 2147483647 |",
+		// complex128 的对齐随平台变化：amd64 为 8，386 为 4
+		fmt.Sprintf("| complex128     | complex128 | 16   | %-4d | 有符号 | -           | -          |", unsafe.Alignof(complex128(0))),
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("表格中没有 %q：\n%s", want, b.String())
+		}
+	}
+}
@@ -0,0 +1,197 @@
+// Package strpool 按预期大小分级复用字符串构建缓冲区。
+//
+// 2_string.go 推荐循环拼接时用 strings.Builder，但每次 new 一个 Builder，
+// 缓冲区都要从零开始按需倍增：拼出 1KB 的字符串要经过 8、16、32、…、1024 多次分配和拷贝。
+// 请求处理等热点路径上频繁拼接短字符串时，可以从 Pool 取出已经扩容好的 Builder，用完归还：
+//
+//	b := pool.Get(256) // 预计长度 256 字节以内
+//	b.WriteString(...)
+//	s := b.String()
+//	pool.Put(b)
+//
+// 与 strings.Builder 不同，Builder.String() 总是拷贝一次：缓冲区归还后会被别人复用，
+// 不能像 strings.Builder 那样把底层字节直接当作字符串返回。
+// 因此已知准确长度时，strings.Builder + Grow 同样只分配一次，池化并没有优势；
+// 池化的收益在于长度只能大致估计、或需要多次增长的场景，见 strpool_test.go 中的基准测试。
+package strpool
+
+import (
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// sizeClasses 缓冲区的容量等级，Get 按预期大小向上取最接近的一级
+var sizeClasses = [...]int{64, 256, 1 << 10, 4 << 10, 16 << 10, 64 << 10}
+
+// MaxSize 可以放回池中的最大缓冲区容量。
+// 超过的缓冲区归还时直接丢弃，避免偶尔一次超大的拼接让池长期占用大块内存。
+const MaxSize = 64 << 10
+
+// Builder 从 Pool 中取出的字符串构建器，用法与 strings.Builder 相同
+type Builder struct {
+	buf    []byte
+	pooled bool // 是否已归还到池中，归还后继续使用会 panic
+}
+
+func (b *Builder) check() {
+	if b.pooled {
+		panic("strpool: Builder 已归还到池中，不能继续使用")
+	}
+}
+
+// Len 已写入的字节数
+func (b *Builder) Len() int { return len(b.buf) }
+
+// Cap 缓冲区容量
+func (b *Builder) Cap() int { return cap(b.buf) }
+
+// Grow 保证至少还能写入 n 个字节而不需要再分配
+func (b *Builder) Grow(n int) {
+	b.check()
+	if n < 0 {
+		panic("strpool: Builder.Grow 的参数为负数")
+	}
+	if cap(b.buf)-len(b.buf) < n {
+		buf := make([]byte, len(b.buf), 2*cap(b.buf)+n)
+		copy(buf, b.buf)
+		b.buf = buf
+	}
+}
+
+// Write 追加 p，总是返回 len(p), nil
+func (b *Builder) Write(p []byte) (int, error) {
+	b.check()
+	b.buf = append(b.buf, p...)
+	return len(p), nil
+}
+
+// WriteString 追加 s，总是返回 len(s), nil
+func (b *Builder) WriteString(s string) (int, error) {
+	b.check()
+	b.buf = append(b.buf, s...)
+	return len(s), nil
+}
+
+// WriteByte 追加一个字节，总是返回 nil
+func (b *Builder) WriteByte(c byte) error {
+	b.check()
+	b.buf = append(b.buf, c)
+	return nil
+}
+
+// WriteRune 追加字符 r 的 UTF-8 编码，返回写入的字节数
+func (b *Builder) WriteRune(r rune) (int, error) {
+	b.check()
+	n := len(b.buf)
+	b.buf = utf8.AppendRune(b.buf, r)
+	return len(b.buf) - n, nil
+}
+
+// String 已写入内容的拷贝。缓冲区之后会被复用，所以不能像 strings.Builder 那样零拷贝返回。
+func (b *Builder) String() string {
+	b.check()
+	return string(b.buf)
+}
+
+// Reset 清空内容，保留缓冲区
+func (b *Builder) Reset() {
+	b.check()
+	b.buf = b.buf[:0]
+}
+
+// Stats Pool 的使用统计
+type Stats struct {
+	Gets    uint64 // Get 次数
+	Hits    uint64 // 从池中取到了缓冲区
+	Misses  uint64 // 池中没有可用的缓冲区，新分配
+	Puts    uint64 // Put 次数
+	Dropped uint64 // 归还时缓冲区超过 MaxSize（或小于最小等级）被丢弃
+}
+
+// HitRate 命中率，没有 Get 过时为 0
+func (s Stats) HitRate() float64 {
+	if s.Gets == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Gets)
+}
+
+// Pool 按容量等级缓存 Builder，零值即可使用，可以并发调用
+type Pool struct {
+	classes [len(sizeClasses)]sync.Pool
+
+	gets, hits, misses, puts, dropped atomic.Uint64
+}
+
+// Get 取出一个空的 Builder，容量至少为 size（不小于 size 的最小等级）。
+// size 超过 MaxSize 时直接新分配，这样的 Builder 归还时会被丢弃。
+func (p *Pool) Get(size int) *Builder {
+	p.gets.Add(1)
+	c := classFor(size)
+	if c < 0 {
+		p.misses.Add(1)
+		return &Builder{buf: make([]byte, 0, size)}
+	}
+	if b, ok := p.classes[c].Get().(*Builder); ok {
+		p.hits.Add(1)
+		b.pooled = false
+		return b
+	}
+	p.misses.Add(1)
+	return &Builder{buf: make([]byte, 0, sizeClasses[c])}
+}
+
+// Put 归还 b，之后不能再使用 b。
+// 缓冲区写入时可能扩容过，按归还时的实际容量放入对应等级；超过 MaxSize 或小于最小等级的丢弃。
+// 重复归还同一个 Builder 会 panic，否则它会被同时交给两个使用者。
+func (p *Pool) Put(b *Builder) {
+	if b == nil {
+		return
+	}
+	if b.pooled {
+		panic("strpool: 重复归还同一个 Builder")
+	}
+	p.puts.Add(1)
+	b.buf = b.buf[:0]
+	c := classOf(cap(b.buf))
+	if cap(b.buf) > MaxSize || c < 0 {
+		p.dropped.Add(1)
+		return
+	}
+	b.pooled = true
+	p.classes[c].Put(b)
+}
+
+// Stats 目前为止的使用统计
+func (p *Pool) Stats() Stats {
+	return Stats{
+		Gets:    p.gets.Load(),
+		Hits:    p.hits.Load(),
+		Misses:  p.misses.Load(),
+		Puts:    p.puts.Load(),
+		Dropped: p.dropped.Load(),
+	}
+}
+
+// classFor 容量不小于 size 的最小等级，超过最大等级返回 -1
+func classFor(size int) int {
+	for i, c := range sizeClasses {
+		if size <= c {
+			return i
+		}
+	}
+	return -1
+}
+
+// classOf 容量为 n 的缓冲区能满足的最大等级，比最小等级还小返回 -1
+func classOf(n int) int {
+	c := -1
+	for i, size := range sizeClasses {
+		if n < size {
+			break
+		}
+		c = i
+	}
+	return c
+}
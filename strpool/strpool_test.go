@@ -0,0 +1,174 @@
+package strpool
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	var p Pool
+	b := p.Get(10)
+	b.WriteString("Go")
+	b.WriteByte(' ')
+	b.WriteRune('语')
+	b.Write([]byte("言"))
+	if got := b.String(); got != "Go 语言" {
+		t.Fatalf("String() = %q", got)
+	}
+	s := b.String()
+	b.Reset()
+	b.WriteString("覆盖")
+	if s != "Go 语言" {
+		t.Fatalf("Reset 后之前返回的字符串被修改：%q", s)
+	}
+	b.Grow(1000)
+	if b.Cap()-b.Len() < 1000 {
+		t.Fatalf("Grow(1000) 后剩余容量 %d", b.Cap()-b.Len())
+	}
+	if b.String() != "覆盖" {
+		t.Fatalf("Grow 后内容变为 %q", b.String())
+	}
+}
+
+func TestPoolClasses(t *testing.T) {
+	var p Pool
+	for _, tt := range []struct{ size, cap int }{
+		{0, 64}, {1, 64}, {64, 64}, {65, 256}, {1000, 1024}, {MaxSize, MaxSize}, {MaxSize + 1, MaxSize + 1},
+	} {
+		if b := p.Get(tt.size); b.Cap() != tt.cap || b.Len() != 0 {
+			t.Errorf("Get(%d) 的容量 = %d，长度 = %d，期望容量 %d", tt.size, b.Cap(), b.Len(), tt.cap)
+		}
+	}
+	if st := p.Stats(); st.Gets != 7 || st.Misses != 7 || st.Hits != 0 {
+		t.Errorf("Stats() = %+v", st)
+	}
+}
+
+func TestPoolReuse(t *testing.T) {
+	var p Pool
+	// sync.Pool 在 GC 时会清空，不保证每次都命中，多试几次
+	for i := 0; i < 100; i++ {
+		b := p.Get(100)
+		if b.Len() != 0 {
+			t.Fatalf("取出的 Builder 不是空的：%q", b.String())
+		}
+		b.WriteString(strings.Repeat("x", 100))
+		p.Put(b)
+	}
+	st := p.Stats()
+	if st.Hits == 0 || st.Hits+st.Misses != st.Gets || st.Puts != 100 || st.Dropped != 0 {
+		t.Errorf("Stats() = %+v", st)
+	}
+	if st.HitRate() <= 0 {
+		t.Errorf("HitRate() = %v", st.HitRate())
+	}
+}
+
+func TestPoolDropsOversized(t *testing.T) {
+	var p Pool
+	b := p.Get(10)
+	b.WriteString(strings.Repeat("x", MaxSize+1)) // 写入时扩容超过 MaxSize
+	p.Put(b)
+	p.Put(p.Get(MaxSize + 1))
+	p.Put(&Builder{}) // 不是从池中取出的零值 Builder，容量不够任何等级
+	if st := p.Stats(); st.Dropped != 3 {
+		t.Errorf("Dropped = %d，期望 3", st.Dropped)
+	}
+}
+
+func TestPoolMisuse(t *testing.T) {
+	var p Pool
+	b := p.Get(10)
+	p.Put(b)
+	for name, f := range map[string]func(){
+		"重复归还":  func() { p.Put(b) },
+		"归还后写入": func() { b.WriteString("x") },
+		"归还后读取": func() { _ = b.String() },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s 应当 panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+var sink string
+
+// BenchmarkBuild 对比每次新建 strings.Builder、预先 Grow 和从 Pool 取出 Builder，
+// 拼接 n 个 16 字节的片段。预期长度只能估计（这里按 n*16 估计等级）时 Pool 只分配一次，
+// 新建的 strings.Builder 要多次扩容；已知准确长度时 strings.Builder + Grow 同样只分配一次。
+func BenchmarkBuild(b *testing.B) {
+	const piece = "hello, gopher!!!"
+	var p Pool
+	for _, n := range []int{4, 16, 64, 256} {
+		size := n * len(piece)
+		b.Run(fmt.Sprintf("%dB/strings.Builder", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var sb strings.Builder
+				for j := 0; j < n; j++ {
+					sb.WriteString(piece)
+				}
+				sink = sb.String()
+			}
+		})
+		b.Run(fmt.Sprintf("%dB/strings.Builder+Grow", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var sb strings.Builder
+				sb.Grow(size)
+				for j := 0; j < n; j++ {
+					sb.WriteString(piece)
+				}
+				sink = sb.String()
+			}
+		})
+		b.Run(fmt.Sprintf("%dB/strpool", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				sb := p.Get(size)
+				for j := 0; j < n; j++ {
+					sb.WriteString(piece)
+				}
+				sink = sb.String()
+				p.Put(sb)
+			}
+		})
+	}
+}
+
+// BenchmarkBuildParallel 多个 goroutine 同时使用同一个 Pool
+func BenchmarkBuildParallel(b *testing.B) {
+	const piece = "hello, gopher!!!"
+	var p Pool
+	b.Run("strings.Builder", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				var sb strings.Builder
+				for j := 0; j < 16; j++ {
+					sb.WriteString(piece)
+				}
+				_ = sb.String()
+			}
+		})
+	})
+	b.Run("strpool", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				sb := p.Get(256)
+				for j := 0; j < 16; j++ {
+					sb.WriteString(piece)
+				}
+				_ = sb.String()
+				p.Put(sb)
+			}
+		})
+	})
+}
@@ -0,0 +1,114 @@
+package strutil
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"unicode/utf8"
+)
+
+// 按字节下标截取 s[i:j] 不会检查下标是否落在字符中间，
+// 截断多字节字符时不会报错，只会悄悄产生无效的 UTF-8（乱码），见 2_string.go 中的 str8[2:4]。
+// 本文件提供截取前的边界检查，以及在调试模式下报告课程中被截断的字符。
+
+// IsBoundary 字节下标 i 是否位于字符边界。0 和 len(s) 都是边界，越界的下标不是。
+// 无效的 UTF-8 字节各自算一个字符，与 for range 的解码方式一致。
+func IsBoundary(s string, i int) bool {
+	_, start, _ := runeAround(s, i)
+	return i >= 0 && i <= len(s) && start == i
+}
+
+// runeAround 返回包含字节下标 i 的字符及其字节范围 [start, end)；i 为 len(s) 时返回 start = end = len(s)
+func runeAround(s string, i int) (r rune, start, end int) {
+	if i < 0 || i >= len(s) {
+		return utf8.RuneError, i, i
+	}
+	// 向前最多找 UTFMax-1 个字节，找到能覆盖 i 的字符起点
+	for j := i; j >= 0 && j > i-utf8.UTFMax; j-- {
+		if !utf8.RuneStart(s[j]) {
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[j:])
+		if j+size > i {
+			return r, j, j + size
+		}
+		break
+	}
+	r, size := utf8.DecodeRuneInString(s[i:])
+	return r, i, i + size
+}
+
+// SliceError 截取的字节下标落在了多字节字符中间
+type SliceError struct {
+	Start, End int  // 截取的字节区间 [Start:End]
+	Index      int  // 落在字符中间的那个下标（Start 或 End）
+	Rune       rune // 被截断的字符
+	RuneStart  int  // 被截断字符的起始字节下标
+	RuneEnd    int  // 被截断字符的结束字节下标（不含）
+}
+
+func (e *SliceError) Error() string {
+	return fmt.Sprintf("strutil: 字节区间 [%d:%d] 中的%s", e.Start, e.End, e.describe())
+}
+
+func (e *SliceError) describe() string {
+	return fmt.Sprintf("下标 %d 截断了字符 %q（U+%04X，占字节 %d~%d）",
+		e.Index, e.Rune, e.Rune, e.RuneStart, e.RuneEnd-1)
+}
+
+// ValidateSlice 检查 s[start:end] 是否合法：下标越界返回包装了 ErrRange 的错误，
+// 下标截断字符返回 *SliceError，指出是哪个下标截断了哪个字符。合法时返回 nil。
+func ValidateSlice(s string, start, end int) error {
+	if start < 0 || start > end || end > len(s) {
+		return fmt.Errorf("%w：字节区间 [%d:%d]，字节数为 %d", ErrRange, start, end, len(s))
+	}
+	for _, i := range [2]int{start, end} {
+		if r, rs, re := runeAround(s, i); rs != i {
+			return &SliceError{Start: start, End: end, Index: i, Rune: r, RuneStart: rs, RuneEnd: re}
+		}
+	}
+	return nil
+}
+
+// SafeSlice 与 s[start:end] 相同，但下标越界或截断字符时返回错误而不是 panic 或乱码
+func SafeSlice(s string, start, end int) (string, error) {
+	if err := ValidateSlice(s, start, end); err != nil {
+		return "", err
+	}
+	return s[start:end], nil
+}
+
+var (
+	debugMu  sync.Mutex
+	debugOut io.Writer
+)
+
+// SetDebug 开启调试模式：之后每次 Slice 截断字符时，把调用位置和被截断的字符写到 w。
+// w 为 nil 时关闭调试模式。
+func SetDebug(w io.Writer) {
+	debugMu.Lock()
+	defer debugMu.Unlock()
+	debugOut = w
+}
+
+// Slice 等价于 s[start:end]（越界同样会 panic），用于课程中演示按字节截取。
+// 调试模式下（见 SetDebug），截断字符时会报告调用位置、被截断的字符及其字节范围。
+func Slice(s string, start, end int) string {
+	debugMu.Lock()
+	w := debugOut
+	debugMu.Unlock()
+	if w != nil {
+		var se *SliceError
+		if errors.As(ValidateSlice(s, start, end), &se) {
+			where := "?"
+			if _, file, line, ok := runtime.Caller(1); ok {
+				where = fmt.Sprintf("%s:%d", filepath.Base(file), line)
+			}
+			fmt.Fprintf(w, "[strutil 调试] %s 截取 %q[%d:%d]：%s\n", where, s, start, end, se.describe())
+		}
+	}
+	return s[start:end]
+}
@@ -0,0 +1,66 @@
+package strutil
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestIsBoundary(t *testing.T) {
+	const s = "Go语言" // 语占字节 2~4，言占字节 5~7
+	want := []bool{true, true, true, false, false, true, false, false, true}
+	for i, w := range want {
+		if got := IsBoundary(s, i); got != w {
+			t.Errorf("IsBoundary(%q, %d) = %t，期望 %t", s, i, got, w)
+		}
+	}
+	if IsBoundary(s, -1) || IsBoundary(s, 9) {
+		t.Errorf("越界的下标不应是字符边界")
+	}
+	// 无效的 UTF-8 字节各自是一个字符
+	if !IsBoundary("\xe4\xbda", 1) || !IsBoundary("a\xbd\xbd", 2) {
+		t.Errorf("无效字节之间应是字符边界")
+	}
+}
+
+func TestValidateSlice(t *testing.T) {
+	const s = "Go语言编程"
+	err := ValidateSlice(s, 2, 4)
+	var se *SliceError
+	if !errors.As(err, &se) {
+		t.Fatalf("ValidateSlice(%q, 2, 4) = %v，期望 *SliceError", s, err)
+	}
+	if se.Index != 4 || se.Rune != '语' || se.RuneStart != 2 || se.RuneEnd != 5 {
+		t.Errorf("ValidateSlice(%q, 2, 4) = %+v", s, se)
+	}
+	if err := ValidateSlice(s, 3, 8); !errors.As(err, &se) || se.Index != 3 {
+		t.Errorf("ValidateSlice(%q, 3, 8) = %v，期望下标 3 截断字符", s, err)
+	}
+	if err := ValidateSlice(s, 2, 20); !errors.Is(err, ErrRange) {
+		t.Errorf("ValidateSlice(%q, 2, 20) = %v，期望 ErrRange", s, err)
+	}
+	if got, err := SafeSlice(s, 2, 8); got != "语言" || err != nil {
+		t.Errorf("SafeSlice(%q, 2, 8) = %q, %v", s, got, err)
+	}
+	if got, err := SafeSlice(s, 2, 4); got != "" || err == nil {
+		t.Errorf("SafeSlice(%q, 2, 4) = %q, %v，期望报错", s, got, err)
+	}
+}
+
+func TestSliceDebug(t *testing.T) {
+	var buf bytes.Buffer
+	SetDebug(&buf)
+	defer SetDebug(nil)
+
+	const s = "Go语言编程"
+	if got := Slice(s, 2, 8); got != "语言" || buf.Len() != 0 {
+		t.Errorf("Slice(%q, 2, 8) = %q，调试输出 %q", s, got, buf.String())
+	}
+	if got := Slice(s, 2, 4); got != s[2:4] {
+		t.Errorf("Slice(%q, 2, 4) = %q，期望与 s[2:4] 相同", s, got)
+	}
+	if out := buf.String(); !strings.Contains(out, "boundary_test.go") || !strings.Contains(out, "'语'") {
+		t.Errorf("调试输出缺少调用位置或被截断的字符：%q", out)
+	}
+}
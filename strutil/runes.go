@@ -0,0 +1,111 @@
+// Package strutil 按字符而不是按字节处理字符串。
+//
+// Go 的字符串是 UTF-8 字节序列，s[i:j] 按字节下标截取，
+// 对 "Go语言编程" 这样的中文字符串很容易截断字符产生乱码（见 2_string.go）。
+// 课程里的做法 string([]rune(s)[:3]) 结果正确，但要先为整个字符串分配一个 []rune。
+// 本包的函数直接在 UTF-8 字节上逐字符前进，返回原字符串的子串，不分配内存。
+//
+// 与 []rune 转换一致，无效的 UTF-8 字节每个算作一个字符。
+package strutil
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// ErrRange 字符下标越界
+var ErrRange = errors.New("strutil: 字符下标越界")
+
+// RuneLen 字符数，等价于 len([]rune(s))
+func RuneLen(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+// RuneAt 第 i 个字符（从 0 开始），等价于 []rune(s)[i]
+func RuneAt(s string, i int) (rune, error) {
+	off, ok := offset(s, 0, i)
+	if !ok || off == len(s) {
+		return utf8.RuneError, rangeError(s, i, i+1)
+	}
+	r, _ := utf8.DecodeRuneInString(s[off:])
+	return r, nil
+}
+
+// Substring 第 start 到 end-1 个字符组成的子串，等价于 string([]rune(s)[start:end])。
+// 下标越界或 start > end 时返回 ErrRange。
+func Substring(s string, start, end int) (string, error) {
+	if start > end {
+		return "", rangeError(s, start, end)
+	}
+	i, ok := offset(s, 0, start)
+	if !ok {
+		return "", rangeError(s, start, end)
+	}
+	j, ok := offset(s, i, end-start)
+	if !ok {
+		return "", rangeError(s, start, end)
+	}
+	return s[i:j], nil
+}
+
+// SubstringClamp 与 Substring 相同，但越界的下标会被收拢到 [0, 字符数] 范围内，
+// start >= end 时返回空字符串
+func SubstringClamp(s string, start, end int) string {
+	start = max(start, 0)
+	if start >= end {
+		return ""
+	}
+	i, ok := offset(s, 0, start)
+	if !ok {
+		return ""
+	}
+	j, ok := offset(s, i, end-start)
+	if !ok {
+		j = len(s)
+	}
+	return s[i:j]
+}
+
+// Left 前 n 个字符，字符数不足 n 时返回整个字符串
+func Left(s string, n int) string {
+	return SubstringClamp(s, 0, n)
+}
+
+// Right 后 n 个字符，字符数不足 n 时返回整个字符串
+func Right(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	skip := RuneLen(s) - n
+	if skip <= 0 {
+		return s
+	}
+	i, _ := offset(s, 0, skip)
+	return s[i:]
+}
+
+// offset 从字节下标 from 开始向后跳过 n 个字符，返回到达的字节下标。
+// 恰好到达字符串末尾是合法的；字符不够跳或 n < 0 时 ok 为 false。
+func offset(s string, from, n int) (int, bool) {
+	if n < 0 {
+		return 0, false
+	}
+	i := from
+	for ; n > 0; n-- {
+		if i >= len(s) {
+			return 0, false
+		}
+		if s[i] < utf8.RuneSelf {
+			i++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return i, true
+}
+
+func rangeError(s string, start, end int) error {
+	return fmt.Errorf("%w：[%d:%d]，字符数为 %d", ErrRange, start, end, RuneLen(s))
+}
@@ -0,0 +1,127 @@
+package strutil
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSubstring(t *testing.T) {
+	const s = "Go语言编程"
+	tests := []struct {
+		start, end int
+		want       string
+		err        bool
+	}{
+		{0, 2, "Go", false},
+		{2, 4, "语言", false},
+		{0, 6, s, false},
+		{6, 6, "", false},
+		{3, 2, "", true},
+		{-1, 2, "", true},
+		{0, 7, "", true},
+	}
+	for _, tt := range tests {
+		got, err := Substring(s, tt.start, tt.end)
+		if (err != nil) != tt.err || got != tt.want {
+			t.Errorf("Substring(%q, %d, %d) = %q, %v", s, tt.start, tt.end, got, err)
+		}
+		if err != nil && !errors.Is(err, ErrRange) {
+			t.Errorf("Substring(%q, %d, %d) 的错误 %v 不是 ErrRange", s, tt.start, tt.end, err)
+		}
+	}
+
+	if got := SubstringClamp(s, -3, 100); got != s {
+		t.Errorf("SubstringClamp(%q, -3, 100) = %q", s, got)
+	}
+	if got := Left(s, 3); got != "Go语" {
+		t.Errorf("Left(%q, 3) = %q", s, got)
+	}
+	if got := Right(s, 2); got != "编程" {
+		t.Errorf("Right(%q, 2) = %q", s, got)
+	}
+	if r, err := RuneAt(s, 2); r != '语' || err != nil {
+		t.Errorf("RuneAt(%q, 2) = %q, %v", s, r, err)
+	}
+	if _, err := RuneAt(s, 6); !errors.Is(err, ErrRange) {
+		t.Errorf("RuneAt(%q, 6) 应返回 ErrRange，实际为 %v", s, err)
+	}
+}
+
+func TestNoAlloc(t *testing.T) {
+	const s = "Go语言编程，Hello 世界"
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = Substring(s, 2, 8)
+		_ = Left(s, 3)
+		_ = Right(s, 2)
+		_, _ = RuneAt(s, 5)
+		_ = RuneLen(s)
+	})
+	if allocs != 0 {
+		t.Errorf("每次调用分配了 %v 次内存，期望 0 次", allocs)
+	}
+}
+
+// FuzzSubstring 与课程中 []rune 转换的做法对照
+func FuzzSubstring(f *testing.F) {
+	f.Add("Go语言编程", 0, 3)
+	f.Add("Go语言编程", 2, 4)
+	f.Add("é́👍🏽", 1, 3)
+	f.Add("\xe4\xbd", 0, 1)
+	f.Add("", 0, 0)
+	f.Fuzz(func(t *testing.T, s string, start, end int) {
+		rs := []rune(s)
+		if RuneLen(s) != len(rs) {
+			t.Fatalf("RuneLen(%q) = %d，[]rune 长度为 %d", s, RuneLen(s), len(rs))
+		}
+
+		got, err := Substring(s, start, end)
+		if start < 0 || end > len(rs) || start > end {
+			if err == nil {
+				t.Fatalf("Substring(%q, %d, %d) 越界却没有报错", s, start, end)
+			}
+		} else {
+			want := string(rs[start:end])
+			if err != nil {
+				t.Fatalf("Substring(%q, %d, %d) 报错：%v", s, start, end, err)
+			}
+			// 无效 UTF-8 字节在 []rune 中变成 U+FFFD，Substring 保留原字节
+			if (utf8.ValidString(s) && got != want) || string([]rune(got)) != want {
+				t.Fatalf("Substring(%q, %d, %d) = %q，[]rune 做法得到 %q", s, start, end, got, want)
+			}
+		}
+
+		clamped := SubstringClamp(s, start, end)
+		lo, hi := min(max(start, 0), len(rs)), min(max(end, 0), len(rs))
+		want := ""
+		if lo < hi {
+			want = string(rs[lo:hi])
+		}
+		if string([]rune(clamped)) != want {
+			t.Fatalf("SubstringClamp(%q, %d, %d) = %q，[]rune 做法得到 %q", s, start, end, clamped, want)
+		}
+
+		if start >= 0 && start < len(rs) {
+			if r, err := RuneAt(s, start); err != nil || r != rs[start] {
+				t.Fatalf("RuneAt(%q, %d) = %q, %v，期望 %q", s, start, r, err, rs[start])
+			}
+		}
+	})
+}
+
+func BenchmarkLeft(b *testing.B) {
+	s := strings.Repeat("Go语言编程，", 100)
+	b.Run("strutil", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = Left(s, 3)
+		}
+	})
+	b.Run("runes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = string([]rune(s)[:3])
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package strutil
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 显示宽度：字符在等宽终端中占的列数。
+// fmt 的 %-10s 按字符数补空格，而中文、全角符号等宽字符占两列，
+// 中英文混排的表格因此对不齐。本文件的函数按列数计算和补齐。
+
+// RuneWidth 字符 r 在终端中占的列数：
+//   - 控制字符、组合附加符号（如 U+0301）、零宽字符（如零宽连接符 U+200D）占 0 列
+//   - 东亚宽字符（中日韩文字、全角符号、大部分 emoji）占 2 列
+//   - 其他字符占 1 列（包括东亚“模糊宽度”字符，如 ①、α）
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || r == 0x7F:
+		return 0
+	case r < 0x7F:
+		return 1 // ASCII 快速路径
+	case zeroWidth(r):
+		return 0
+	case unicode.Is(wide, r):
+		return 2
+	}
+	return 1
+}
+
+func zeroWidth(r rune) bool {
+	switch {
+	case r >= 0x80 && r < 0xA0: // C1 控制字符
+		return true
+	case r >= 0x1160 && r <= 0x11FF: // 谚文中声、终声，与前面的初声组合显示
+		return true
+	}
+	// Mn、Me：组合附加符号，含变体选择符；Cf：格式字符，含零宽空格、零宽连接符
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf)
+}
+
+// Width 字符串在终端中占的列数
+func Width(s string) int {
+	w := 0
+	for _, r := range s {
+		w += RuneWidth(r)
+	}
+	return w
+}
+
+// Truncate 把 s 截断到不超过 width 列，被截断时在末尾加上 tail（如 "…"），
+// tail 的宽度计算在 width 之内。不会截断字符，紧跟在保留字符后的组合符号一起保留。
+// width 不大于 0 时返回空串。
+func Truncate(s string, width int, tail string) string {
+	if width <= 0 {
+		return ""
+	}
+	if Width(s) <= width {
+		return s
+	}
+	tw := Width(tail)
+	if tw > width {
+		return Truncate(tail, width, "")
+	}
+	end, w := 0, 0
+	for i, r := range s {
+		rw := RuneWidth(r)
+		if w+rw > width-tw {
+			break
+		}
+		w += rw
+		end = i + utf8.RuneLen(r)
+	}
+	return s[:end] + tail
+}
+
+// PadRight 在右侧补空格到 width 列（左对齐），已经不少于 width 列时原样返回
+func PadRight(s string, width int) string {
+	return s + spaces(width-Width(s))
+}
+
+// PadLeft 在左侧补空格到 width 列（右对齐）
+func PadLeft(s string, width int) string {
+	return spaces(width-Width(s)) + s
+}
+
+// Center 两侧补空格到 width 列（居中），多出的一个空格补在右侧
+func Center(s string, width int) string {
+	n := width - Width(s)
+	if n <= 0 {
+		return s
+	}
+	return spaces(n/2) + s + spaces(n-n/2)
+}
+
+// Fit 截断或补齐到恰好 width 列（左对齐），适合输出固定宽度的表格列
+func Fit(s string, width int, tail string) string {
+	return PadRight(Truncate(s, width, tail), width)
+}
+
+func spaces(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strings.Repeat(" ", n)
+}
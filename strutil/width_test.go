@@ -0,0 +1,51 @@
+package strutil
+
+import "testing"
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"Tom", 3},
+		{"张三", 4},
+		{"全角ＡＢ", 8},
+		{"ｶﾀｶﾅ", 4},     // 半角片假名占一列
+		{"e\u0301", 1},  // e + U+0301 组合重音
+		{"a\u200bb", 2}, // 零宽空格
+		{"👍", 2},
+		{"\t\n", 0},
+	}
+	for _, tt := range tests {
+		if got := Width(tt.s); got != tt.want {
+			t.Errorf("Width(%q) = %d，期望 %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestTruncatePad(t *testing.T) {
+	tests := []struct {
+		fn   func() string
+		want string
+	}{
+		{func() string { return Truncate("李老师张三", 7, "…") }, "李老师…"},
+		{func() string { return Truncate("李老师张三", 6, "…") }, "李老…"},
+		{func() string { return Truncate("张三", 4, "…") }, "张三"},
+		{func() string { return Truncate("张三", 1, "……") }, "…"},
+		{func() string { return Truncate("e\u0301e\u0301", 2, "") }, "e\u0301e\u0301"},
+		{func() string { return Truncate("张三", 0, "…") }, ""},
+		{func() string { return Truncate("张三", -1, "…") }, ""},
+		{func() string { return Truncate("", -1, "…") }, ""},
+		{func() string { return PadRight("张三", 6) }, "张三  "},
+		{func() string { return PadLeft("张三", 6) }, "  张三"},
+		{func() string { return Center("张三", 9) }, "  张三   "},
+		{func() string { return Fit("北京市海淀区", 8, "…") }, "北京市… "},
+		{func() string { return Fit("北京市海淀区", 0, "…") }, ""},
+		{func() string { return Fit("北京市海淀区", -3, "…") }, ""},
+	}
+	for i, tt := range tests {
+		if got := tt.fn(); got != tt.want {
+			t.Errorf("第 %d 个用例：得到 %q，期望 %q", i+1, got, tt.want)
+		}
+	}
+}